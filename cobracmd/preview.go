@@ -0,0 +1,55 @@
+package cobracmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/flowdev/fdialog/preview"
+)
+
+var previewCmdData = struct {
+	format   string
+	fileName string
+	lenient  bool
+	watch    bool
+}{}
+
+// previewCmd represents the preview command
+var previewCmd = &cobra.Command{
+	Use:   "preview",
+	Short: "preview a UI description while designing it",
+	Long: `Preview a UI Description While Designing It
+
+The main window of the UI description is displayed.
+Actions that would end the app or write output (exit, close and write)
+are only logged.
+With the watch flag the preview is rebuilt whenever the file changes.
+Errors in the UI description are displayed in the preview window.`,
+	Args: cobra.NoArgs,
+	Run:  doPreview,
+}
+
+func init() {
+	rootCmd.AddCommand(previewCmd)
+
+	previewCmd.Flags().StringVarP(&previewCmdData.fileName, "file", "f", "",
+		"name of file with UI description")
+	_ = previewCmd.MarkFlagRequired("file")
+	previewCmd.Flags().StringVarP(&previewCmdData.format, "format", "t", "uidl",
+		"format of the UI description (valid values are: 'json' or 'uidl')")
+	previewCmd.Flags().BoolVarP(&previewCmdData.lenient, "lenient", "l", false,
+		"if flag is given, additional attributes in the UI description are only warned about")
+	previewCmd.Flags().BoolVarP(&previewCmdData.watch, "watch", "w", false,
+		"if flag is given, the preview is rebuilt whenever the file changes")
+}
+
+func doPreview(_ *cobra.Command, _ []string) {
+	err := preview.Run(previewCmdData.fileName, previewCmdData.format,
+		!previewCmdData.lenient, previewCmdData.watch)
+	if err != nil {
+		log.Printf("ERROR: Unable to preview UI description: %v", err)
+		os.Exit(14)
+	}
+}
@@ -5,11 +5,14 @@ import (
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 
 	"github.com/flowdev/fdialog/parse"
 	"github.com/flowdev/fdialog/run"
+	"github.com/flowdev/fdialog/ui"
+	"github.com/flowdev/fdialog/ui/dialog"
 )
 
 var runCmdData = struct {
@@ -25,7 +28,8 @@ var runCmd = &cobra.Command{
 	Short: "run a description for a UI",
 	Long: `Run a Description For a User Interface And Display the Resulting GUI
 
-If no file or URL is given, the UI description is read from standard input.`,
+If no file or URL is given, the UI description is read from standard input.
+Progress dialogs read standard input, too, so they need the --file flag.`,
 	Args: cobra.NoArgs,
 	Run:  doRun,
 }
@@ -54,6 +58,7 @@ func doRun(_ *cobra.Command, _ []string) {
 			log.Printf("ERROR: Could not open UI description file: %v", err)
 			os.Exit(11)
 		}
+		ui.SetBaseDir(filepath.Dir(runCmdData.fileName))
 	} else {
 		rd = os.Stdin
 	}
@@ -66,5 +71,10 @@ func doRun(_ *cobra.Command, _ []string) {
 	if ok := valid.UIDescription(uiDescr, !runCmdData.lenient); !ok {
 		os.Exit(13)
 	}
+	if rd == os.Stdin && dialog.ReadsInput(uiDescr) {
+		log.Printf("ERROR: A progress dialog needs standard input but the UI description has been read from it; " +
+			"please use the --file flag")
+		os.Exit(13)
+	}
 	run.UIDescription(uiDescr)
 }
@@ -0,0 +1,122 @@
+package cobracmd
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/flowdev/fdialog/parse"
+	"github.com/flowdev/fdialog/screenshot"
+	"github.com/flowdev/fdialog/ui"
+	"github.com/flowdev/fdialog/valid"
+)
+
+var screenshotCmdData = struct {
+	format   string
+	fileName string
+	outName  string
+	golden   string
+	theme    string
+	width    float32
+	height   float32
+	lenient  bool
+}{}
+
+// screenshotCmd represents the screenshot command
+var screenshotCmd = &cobra.Command{
+	Use:   "screenshot",
+	Short: "render a UI description to a PNG file without display",
+	Long: `Render a UI Description to a PNG File Without Display
+
+The main window of the UI description is rendered including all dialogs.
+If no file is given, the UI description is read from standard input.
+
+If a golden PNG file is given, the rendered image is compared to it
+and the command fails if they differ.`,
+	Args: cobra.NoArgs,
+	Run:  doScreenshot,
+}
+
+func init() {
+	rootCmd.AddCommand(screenshotCmd)
+
+	screenshotCmd.Flags().StringVarP(&screenshotCmdData.fileName, "file", "f", "",
+		"name of file with UI description")
+	screenshotCmd.Flags().StringVarP(&screenshotCmdData.format, "format", "t", "uidl",
+		"format of the UI description (valid values are: 'json' or 'uidl')")
+	screenshotCmd.Flags().BoolVarP(&screenshotCmdData.lenient, "lenient", "l", false,
+		"if flag is given, additional attributes in the UI description are only warned about")
+	screenshotCmd.Flags().StringVarP(&screenshotCmdData.outName, "out", "o", "",
+		"name of the PNG file to write (default: name of the UI description file with '.png' or 'screenshot.png')")
+	screenshotCmd.Flags().StringVarP(&screenshotCmdData.golden, "golden", "g", "",
+		"name of a golden PNG file to compare the rendered image to")
+	screenshotCmd.Flags().StringVarP(&screenshotCmdData.theme, "theme", "m", screenshot.ThemeLight,
+		"theme to use (valid values are: 'light' or 'dark')")
+	screenshotCmd.Flags().Float32VarP(&screenshotCmdData.width, "width", "x", 0,
+		"width of the image (default: width of the main window)")
+	screenshotCmd.Flags().Float32VarP(&screenshotCmdData.height, "height", "y", 0,
+		"height of the image (default: height of the main window)")
+}
+
+func doScreenshot(_ *cobra.Command, _ []string) {
+	var rd io.Reader
+	var err error
+
+	outName := screenshotCmdData.outName
+	if screenshotCmdData.fileName != "" {
+		rd, err = os.Open(screenshotCmdData.fileName)
+		if err != nil {
+			log.Printf("ERROR: Could not open UI description file: %v", err)
+			os.Exit(11)
+		}
+		ui.SetBaseDir(filepath.Dir(screenshotCmdData.fileName))
+	} else {
+		rd = os.Stdin
+	}
+	if outName == "" && screenshotCmdData.golden == "" { // write to default file
+		outName = "screenshot.png"
+		if screenshotCmdData.fileName != "" {
+			base := filepath.Base(screenshotCmdData.fileName)
+			outName = strings.TrimSuffix(base, filepath.Ext(base)) + ".png"
+		}
+	}
+
+	uiDescr, err := parse.UIDescription(rd, screenshotCmdData.fileName, screenshotCmdData.format)
+	if err != nil {
+		log.Printf("ERROR: Unable to parse UI description:\n%v", err)
+		os.Exit(12)
+	}
+	if ok := valid.UIDescription(uiDescr, !screenshotCmdData.lenient); !ok {
+		os.Exit(13)
+	}
+
+	img, err := screenshot.Capture(uiDescr, screenshotCmdData.width, screenshotCmdData.height,
+		screenshotCmdData.theme)
+	if err != nil {
+		log.Printf("ERROR: Unable to render UI description: %v", err)
+		os.Exit(14)
+	}
+	if outName != "" {
+		err = screenshot.WritePNG(img, outName)
+		if err != nil {
+			log.Printf("ERROR: Unable to write PNG file: %v", err)
+			os.Exit(14)
+		}
+	}
+	if screenshotCmdData.golden != "" {
+		ok, err := screenshot.MatchesPNG(img, screenshotCmdData.golden)
+		if err != nil {
+			log.Printf("ERROR: Unable to compare with golden PNG file: %v", err)
+			os.Exit(14)
+		}
+		if !ok {
+			log.Printf("ERROR: The rendered image differs from the golden PNG file %q",
+				screenshotCmdData.golden)
+			os.Exit(15)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package cobracmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/flowdev/fdialog/uitest"
+)
+
+// testCmd represents the test command
+var testCmd = &cobra.Command{
+	Use:   "test spec.yaml...",
+	Short: "run test scripts against UI descriptions",
+	Long: `Run Test Scripts Against UI Descriptions Without Display
+
+Each test script (in YAML format) references a UI description file,
+simulates user interactions step by step and compares the exit code and
+the written output with the expected values.
+
+Example test script:
+
+  file: register.uidl
+  steps:
+    - type: {item: main.registerForm.name, text: Jane Doe}
+    - select: {item: main.registerForm.gender, option: female}
+    - submit: main.registerForm
+  expect:
+    exitCode: 0
+    output:
+      - {name: Jane Doe, gender: female}
+
+Supported steps are: type, select, check, click, submit, cancel and key.`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  doTest,
+}
+
+func init() {
+	rootCmd.AddCommand(testCmd)
+}
+
+func doTest(_ *cobra.Command, args []string) {
+	failed := 0
+	for _, fileName := range args {
+		err := uitest.RunFile(fileName)
+		if err != nil {
+			log.Printf("FAIL: %s:\n%v", fileName, err)
+			failed++
+			continue
+		}
+		log.Printf("PASS: %s", fileName)
+	}
+	if failed > 0 {
+		log.Printf("ERROR: %d of %d test scripts failed", failed, len(args))
+		os.Exit(16)
+	}
+}
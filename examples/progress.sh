@@ -0,0 +1,12 @@
+#!/bin/bash
+
+dir="$(dirname $0)"
+base="$(basename $0 .sh)"
+name="$dir/$base"
+
+for file in /etc/*.conf; do
+  echo "# Copying $(basename $file) ..."
+  sleep 0.1
+  echo "$((++i * 10))"
+done | "$name.uidl"
+echo "RET: $?"
@@ -0,0 +1,459 @@
+// Package expr implements the small expression language used for conditions
+// in UI descriptions (e.g. `form.gender == "female" && form.age >= 18`).
+//
+// Expressions consist of:
+//   - references to stored values: keys or groups and keys separated by dots (e.g. `form.age`)
+//   - literals: numbers (`18`, `-1.5`), strings (`"female"` or `'female'`), `true`, `false` and `null`
+//   - comparisons: `==`, `!=`, `<`, `<=`, `>` and `>=`
+//   - logical operators: `!`, `&&` and `||`
+//   - parentheses for grouping
+//
+// References to unknown values evaluate to `null`.
+// Comparisons of values with different types are false (`!=` is true).
+// In a boolean context `null`, `false`, `0`, `""` and empty lists are false;
+// all other values are true.
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// LookupFunc returns the stored value for a reference.
+// It returns `false` if nothing was found.
+type LookupFunc func(ref string) (any, bool)
+
+// Expression is a parsed expression that can be evaluated multiple times.
+type Expression struct {
+	source string
+	root   node
+	refs   []string
+}
+
+// Parse parses an expression.
+func Parse(source string) (*Expression, error) {
+	p := &parser{source: source}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, p.errorf("unexpected %q", p.tokens[p.pos].text)
+	}
+	return &Expression{source: source, root: root, refs: p.refs}, nil
+}
+
+// String returns the source of the expression.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// References returns all references to stored values in the order of their
+// first occurrence.
+func (e *Expression) References() []string {
+	return e.refs
+}
+
+// Eval evaluates the expression with the values found by lookup.
+func (e *Expression) Eval(lookup LookupFunc) any {
+	return e.root.eval(lookup)
+}
+
+// Bool evaluates the expression in a boolean context.
+func (e *Expression) Bool(lookup LookupFunc) bool {
+	return Truthy(e.Eval(lookup))
+}
+
+// Truthy returns the value in a boolean context.
+func Truthy(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case []any:
+		return len(v) > 0
+	case map[string]any:
+		return len(v) > 0
+	}
+	if f, ok := toFloat(v); ok {
+		return f != 0
+	}
+	return true
+}
+
+// ---------------------------------------------------------------------------
+// Evaluation
+//
+
+type node interface {
+	eval(lookup LookupFunc) any
+}
+
+type literal struct {
+	value any
+}
+
+func (n literal) eval(_ LookupFunc) any {
+	return n.value
+}
+
+type reference struct {
+	ref string
+}
+
+func (n reference) eval(lookup LookupFunc) any {
+	v, _ := lookup(n.ref)
+	return v
+}
+
+type not struct {
+	operand node
+}
+
+func (n not) eval(lookup LookupFunc) any {
+	return !Truthy(n.operand.eval(lookup))
+}
+
+type logical struct {
+	op          string
+	left, right node
+}
+
+func (n logical) eval(lookup LookupFunc) any {
+	left := Truthy(n.left.eval(lookup))
+	if n.op == "&&" {
+		return left && Truthy(n.right.eval(lookup))
+	}
+	return left || Truthy(n.right.eval(lookup))
+}
+
+type comparison struct {
+	op          string
+	left, right node
+}
+
+func (n comparison) eval(lookup LookupFunc) any {
+	left, right := n.left.eval(lookup), n.right.eval(lookup)
+	switch n.op {
+	case "==":
+		return equal(left, right)
+	case "!=":
+		return !equal(left, right)
+	}
+	c, ok := compare(left, right)
+	if !ok {
+		return false
+	}
+	switch n.op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	default: // ">="
+		return c >= 0
+	}
+}
+
+func equal(a, b any) bool {
+	if c, ok := compare(a, b); ok {
+		return c == 0
+	}
+	switch a := a.(type) {
+	case nil:
+		return b == nil
+	case bool:
+		bb, ok := b.(bool)
+		return ok && a == bb
+	}
+	return false
+}
+
+// compare compares two numbers or two strings.
+func compare(a, b any) (int, bool) {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case fa < fb:
+			return -1, true
+		case fa > fb:
+			return 1, true
+		}
+		return 0, true
+	}
+	sa, ok := a.(string)
+	if !ok {
+		return 0, false
+	}
+	sb, ok := b.(string)
+	if !ok {
+		return 0, false
+	}
+	return strings.Compare(sa, sb), true
+}
+
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// ---------------------------------------------------------------------------
+// Parsing
+//
+
+type tokenKind int
+
+const (
+	tokenOp tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenName
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value any
+	pos   int
+}
+
+type parser struct {
+	source string
+	tokens []token
+	pos    int
+	refs   []string
+}
+
+func (p *parser) tokenize() error {
+	src := p.source
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"' || c == '\'':
+			j := i + 1
+			var sb strings.Builder
+			for ; j < len(src) && src[j] != c; j++ {
+				if src[j] == '\\' && j+1 < len(src) {
+					j++
+				}
+				sb.WriteByte(src[j])
+			}
+			if j >= len(src) {
+				return fmt.Errorf("in expression %q: unterminated string at position %d", src, i+1)
+			}
+			p.tokens = append(p.tokens, token{kind: tokenString, text: src[i : j+1], value: sb.String(), pos: i})
+			i = j + 1
+		case isDigit(c) || (c == '-' && i+1 < len(src) && isDigit(src[i+1]) && p.operandExpected()):
+			j := i + 1
+			for j < len(src) && (isDigit(src[j]) || src[j] == '.') {
+				j++
+			}
+			f, err := strconv.ParseFloat(src[i:j], 64)
+			if err != nil {
+				return fmt.Errorf("in expression %q: invalid number %q at position %d", src, src[i:j], i+1)
+			}
+			p.tokens = append(p.tokens, token{kind: tokenNumber, text: src[i:j], value: f, pos: i})
+			i = j
+		case isNameStart(rune(c)):
+			j := i + 1
+			for j < len(src) && (isNameStart(rune(src[j])) || isDigit(src[j]) || src[j] == '.') {
+				j++
+			}
+			p.tokens = append(p.tokens, token{kind: tokenName, text: src[i:j], pos: i})
+			i = j
+		default:
+			op := ""
+			for _, o := range []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")"} {
+				if strings.HasPrefix(src[i:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return fmt.Errorf("in expression %q: unexpected character %q at position %d", src, c, i+1)
+			}
+			p.tokens = append(p.tokens, token{kind: tokenOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return nil
+}
+
+// operandExpected returns true if the next token has to be an operand
+// (so a '-' starts a negative number).
+func (p *parser) operandExpected() bool {
+	if len(p.tokens) == 0 {
+		return true
+	}
+	last := p.tokens[len(p.tokens)-1]
+	return last.kind == tokenOp && last.text != ")"
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptOp("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logical{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptOp("&&") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = logical{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if p.acceptOp("!") {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return not{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.acceptOp(op) {
+			right, err := p.parsePrimary()
+			if err != nil {
+				return nil, err
+			}
+			return comparison{op: op, left: left, right: right}, nil
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, fmt.Errorf("in expression %q: unexpected end", p.source)
+	}
+	tok := p.tokens[p.pos]
+	p.pos++
+	switch tok.kind {
+	case tokenNumber, tokenString:
+		return literal{value: tok.value}, nil
+	case tokenName:
+		switch tok.text {
+		case "true":
+			return literal{value: true}, nil
+		case "false":
+			return literal{value: false}, nil
+		case "null":
+			return literal{value: nil}, nil
+		}
+		if strings.HasSuffix(tok.text, ".") || strings.Contains(tok.text, "..") {
+			p.pos--
+			return nil, p.errorf("invalid reference %q", tok.text)
+		}
+		p.addRef(tok.text)
+		return reference{ref: tok.text}, nil
+	}
+	if tok.text == "(" {
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.acceptOp(")") {
+			return nil, p.errorf("missing %q", ")")
+		}
+		return n, nil
+	}
+	p.pos--
+	return nil, p.errorf("unexpected %q", tok.text)
+}
+
+func (p *parser) acceptOp(op string) bool {
+	if p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenOp && p.tokens[p.pos].text == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) addRef(ref string) {
+	for _, r := range p.refs {
+		if r == ref {
+			return
+		}
+	}
+	p.refs = append(p.refs, ref)
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	pos := len(p.source)
+	if p.pos < len(p.tokens) {
+		pos = p.tokens[p.pos].pos
+	}
+	return fmt.Errorf("in expression %q at position %d: %s", p.source, pos+1, fmt.Sprintf(format, args...))
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isNameStart(r rune) bool {
+	return r == '_' || (r < unicode.MaxASCII && unicode.IsLetter(r))
+}
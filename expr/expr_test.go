@@ -0,0 +1,125 @@
+package expr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpression(t *testing.T) {
+	values := map[string]any{
+		"form.gender": "female",
+		"form.age":    float64(21),
+		"form.count":  int64(3),
+		"form.news":   true,
+		"form.tags":   []any{},
+		"name":        "Jane",
+	}
+	lookup := func(ref string) (any, bool) {
+		v, ok := values[ref]
+		return v, ok
+	}
+
+	tests := []struct {
+		name      string
+		givenExpr string
+		wantValue bool
+		wantRefs  []string
+		wantErr   bool
+	}{
+		{
+			name:      "example",
+			givenExpr: `form.gender == "female" && form.age >= 18`,
+			wantValue: true,
+			wantRefs:  []string{"form.gender", "form.age"},
+		}, {
+			name:      "single-quotes",
+			givenExpr: `form.gender != 'male'`,
+			wantValue: true,
+			wantRefs:  []string{"form.gender"},
+		}, {
+			name:      "or-and-precedence",
+			givenExpr: `false && true || form.news`,
+			wantValue: true,
+			wantRefs:  []string{"form.news"},
+		}, {
+			name:      "parentheses",
+			givenExpr: `false && (true || form.news)`,
+			wantValue: false,
+			wantRefs:  []string{"form.news"},
+		}, {
+			name:      "not",
+			givenExpr: `!form.news || !(form.age < 18)`,
+			wantValue: true,
+			wantRefs:  []string{"form.news", "form.age"},
+		}, {
+			name:      "integer-and-negative-number",
+			givenExpr: `form.count == 3 && form.count > -1.5`,
+			wantValue: true,
+			wantRefs:  []string{"form.count"},
+		}, {
+			name:      "unknown-reference-is-null",
+			givenExpr: `form.missing == null && !form.missing`,
+			wantValue: true,
+			wantRefs:  []string{"form.missing"},
+		}, {
+			name:      "different-types",
+			givenExpr: `form.age == "21" || form.age < "x"`,
+			wantValue: false,
+			wantRefs:  []string{"form.age"},
+		}, {
+			name:      "truthy-values",
+			givenExpr: `name && !form.tags && form.age`,
+			wantValue: true,
+			wantRefs:  []string{"name", "form.tags", "form.age"},
+		}, {
+			name:      "string-order",
+			givenExpr: `name < "Joe"`,
+			wantValue: true,
+			wantRefs:  []string{"name"},
+		}, {
+			name:      "missing-operand",
+			givenExpr: `form.age >=`,
+			wantErr:   true,
+		}, {
+			name:      "missing-parenthesis",
+			givenExpr: `(form.news || true`,
+			wantErr:   true,
+		}, {
+			name:      "unterminated-string",
+			givenExpr: `name == "Jane`,
+			wantErr:   true,
+		}, {
+			name:      "unknown-operator",
+			givenExpr: `form.age = 18`,
+			wantErr:   true,
+		}, {
+			name:      "trailing-token",
+			givenExpr: `form.news true`,
+			wantErr:   true,
+		}, {
+			name:      "invalid-reference",
+			givenExpr: `form..age > 1`,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := Parse(tt.givenExpr)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := e.Bool(lookup); got != tt.wantValue {
+				t.Errorf("value got %v, want %v", got, tt.wantValue)
+			}
+			if got := e.References(); !reflect.DeepEqual(got, tt.wantRefs) {
+				t.Errorf("references got %q, want %q", got, tt.wantRefs)
+			}
+		})
+	}
+}
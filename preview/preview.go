@@ -0,0 +1,272 @@
+// Package preview shows a UI description in a single window and
+// rebuilds the content of that window whenever the description file changes.
+// Actions that would end the app or write output are only logged.
+package preview
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/flowdev/fdialog/parse"
+	"github.com/flowdev/fdialog/run"
+	"github.com/flowdev/fdialog/ui"
+	"github.com/flowdev/fdialog/valid"
+)
+
+// debounceDelay is the time we wait after the last change of the file
+// before reloading it (editors often write files in multiple steps).
+const debounceDelay = 150 * time.Millisecond
+
+type previewer struct {
+	fileName string
+	format   string
+	strict   bool
+	win      fyne.Window
+	mutex    sync.Mutex
+}
+
+// Run shows the UI description in the given file.
+// If watch is true, the file is watched and the preview is rebuilt
+// on every change.
+// Run blocks until the preview window is closed.
+func Run(fileName, format string, strict, watch bool) error {
+	p := &previewer{
+		fileName: fileName,
+		format:   format,
+		strict:   strict,
+	}
+
+	ui.NewApp("org.flowdev.fdialog.preview")
+	ui.SetBaseDir(filepath.Dir(fileName))
+	run.DisableTimeouts(true)
+	run.SetNotifier(func(n *fyne.Notification) {
+		log.Printf("INFO: preview intercepted notification: %q: %q", n.Title, n.Content)
+	})
+	run.SetOpener(func(u *url.URL) error {
+		log.Printf("INFO: preview intercepted open of: %s", u)
+		return nil
+	})
+	err := interceptActions()
+	if err != nil {
+		return err
+	}
+	p.win = ui.NewWindow("fdialog preview")
+	p.win.SetOnClosed(func() {
+		ui.ExitApp(0)
+	})
+	p.reload()
+
+	if watch {
+		watcher, err := p.watch()
+		if err != nil {
+			return err
+		}
+		defer watcher.Close()
+	}
+
+	p.win.Show()
+	ui.RunApp()
+	return nil
+}
+
+// reload parses and validates the UI description again and rebuilds
+// the content of the preview window.
+// All errors are shown in an overlay instead of ending the preview.
+func (p *previewer) reload() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	logBuf := &bytes.Buffer{}
+	oldLogOutput := log.Writer()
+	log.SetOutput(io.MultiWriter(oldLogOutput, logBuf))
+	defer log.SetOutput(oldLogOutput)
+
+	clearWindow(p.win)
+	ui.DeleteAllIDs()
+	ui.DeleteAllValues()
+	ui.DeleteAllObjects()
+
+	uiDescr, err := p.parse()
+	if err != nil {
+		p.showErrors(err.Error())
+		return
+	}
+	if ok := valid.UIDescription(uiDescr, p.strict); !ok {
+		p.showErrors(logBuf.String())
+		return
+	}
+	mainWin, ok := uiDescr.Get(ui.WinMain)
+	if !ok {
+		p.showErrors("unable to find main window in UI description")
+		return
+	}
+	if mainWin[ui.AttrKeyword] != ui.KeywordWindow {
+		p.showErrors(fmt.Sprintf("command with name 'main' is not a window but a: %q",
+			mainWin[ui.AttrKeyword]))
+		return
+	}
+
+	title, _ := mainWin["title"].(string)
+	p.win.SetTitle("Preview: " + title)
+	width, height := run.GetSize(mainWin)
+	if width > 0 && height > 0 {
+		p.win.Resize(fyne.NewSize(width, height))
+	}
+	if children, ok := mainWin[ui.AttrChildren]; ok {
+		run.WindowChildren(children, ui.WinMain, p.win, uiDescr)
+	}
+	log.Printf("INFO: preview of %q updated", p.fileName)
+}
+
+func (p *previewer) parse() (ui.CommandsDescr, error) {
+	rd, err := os.Open(p.fileName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open UI description file: %w", err)
+	}
+	defer rd.Close()
+
+	uiDescr, err := parse.UIDescription(rd, p.fileName, p.format)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse UI description:\n%w", err)
+	}
+	return uiDescr, nil
+}
+
+func (p *previewer) showErrors(msg string) {
+	text := widget.NewLabel(msg)
+	text.TextStyle = fyne.TextStyle{Monospace: true}
+	text.Wrapping = fyne.TextWrapWord
+	title := widget.NewLabel("Errors in " + filepath.Base(p.fileName))
+	title.TextStyle = fyne.TextStyle{Bold: true}
+	content := container.NewBorder(title, nil, nil, nil, container.NewVScroll(text))
+
+	popUp := widget.NewModalPopUp(content, p.win.Canvas())
+	popUp.Resize(p.win.Canvas().Size())
+	popUp.Show()
+}
+
+// watch watches the directory of the file because many editors replace
+// a file instead of writing to it.
+func (p *previewer) watch() (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("unable to watch UI description file: %w", err)
+	}
+	absName, err := filepath.Abs(p.fileName)
+	if err != nil {
+		_ = watcher.Close()
+		return nil, fmt.Errorf("unable to watch UI description file: %w", err)
+	}
+	err = watcher.Add(filepath.Dir(absName))
+	if err != nil {
+		_ = watcher.Close()
+		return nil, fmt.Errorf("unable to watch UI description file: %w", err)
+	}
+
+	go func() {
+		var timer *time.Timer
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Name != absName || !event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+					continue
+				}
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = time.AfterFunc(debounceDelay, func() { ui.Do(p.reload) })
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("ERROR: watching %q: %v", p.fileName, err)
+			}
+		}
+	}()
+	return watcher, nil
+}
+
+// clearWindow removes everything from the window, so it can be rebuilt.
+func clearWindow(win fyne.Window) {
+	overlays := win.Canvas().Overlays()
+	for _, overlay := range overlays.List() {
+		overlays.Remove(overlay)
+	}
+	ui.RemoveShortcuts(win)
+	win.SetMainMenu(nil)
+	win.SetContent(widget.NewLabel(""))
+}
+
+// ---------------------------------------------------------------------------
+// Intercepted Actions
+//
+
+// interceptActions replaces all actions that would end the preview
+// or write to standard output.
+func interceptActions() error {
+	err := ui.RegisterAction("exit", previewExit)
+	if err != nil {
+		return err
+	}
+	err = ui.RegisterAction("close", previewClose)
+	if err != nil {
+		return err
+	}
+	err = ui.RegisterAction("exec", previewExec)
+	if err != nil {
+		return err
+	}
+	err = ui.RegisterAction("http", previewHTTP)
+	if err != nil {
+		return err
+	}
+	return ui.RegisterAction("write", previewWrite)
+}
+
+func previewExit(exitDescr ui.AttributesDescr, fullName string, _ fyne.Window, _ ui.CommandsDescr) {
+	code := "default"
+	if exitDescr["code"] != nil {
+		code = fmt.Sprint(exitDescr["code"])
+	}
+	log.Printf("INFO: for %q: preview intercepted exit with code: %s", fullName, code)
+}
+
+func previewClose(_ ui.AttributesDescr, fullName string, _ fyne.Window, _ ui.CommandsDescr) {
+	log.Printf("INFO: for %q: preview intercepted close", fullName)
+}
+
+func previewExec(execDescr ui.AttributesDescr, fullName string, _ fyne.Window, _ ui.CommandsDescr) {
+	log.Printf("INFO: for %q: preview intercepted exec of: %q %q",
+		fullName, execDescr["command"], ui.AnysToStrings(execDescr["args"]))
+}
+
+func previewHTTP(httpDescr ui.AttributesDescr, fullName string, _ fyne.Window, _ ui.CommandsDescr) {
+	method := "POST"
+	if m, ok := httpDescr["method"].(string); ok {
+		method = m
+	}
+	log.Printf("INFO: for %q: preview intercepted HTTP request: %s %s", fullName, method, httpDescr["url"])
+}
+
+func previewWrite(writeDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) {
+	buf := &bytes.Buffer{}
+	run.SetOutput(buf)
+	defer run.SetOutput(nil)
+	run.Write(writeDescr, fullName, win, uiDescr)
+	log.Printf("INFO: for %q: preview intercepted write: %s", fullName, bytes.TrimSpace(buf.Bytes()))
+}
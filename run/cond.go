@@ -0,0 +1,76 @@
+package run
+
+import (
+	"log"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/flowdev/fdialog/expr"
+	"github.com/flowdev/fdialog/ui"
+)
+
+// If evaluates the condition and runs the child "then" if it is true
+// or the (optional) child "else" otherwise.
+// References in the condition are looked up in the group of the action.
+func If(ifDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) {
+	group, _ := ifDescr[ui.AttrGroup].(string)
+	condition, ok := ifDescr["condition"].(string) // condition is required and validated
+	if !ok {
+		log.Printf("ERROR: for %q: the condition is missing", fullName)
+		return
+	}
+	cond, err := expr.Parse(condition)
+	if err != nil {
+		log.Printf("ERROR: for %q: %v", fullName, err)
+		return
+	}
+	name := ui.NameElse
+	if cond.Bool(ValueLookup(group)) {
+		name = ui.NameThen
+	}
+	runChild(ifDescr, name, fullName, win, uiDescr)
+}
+
+// Switch runs the child with the name of the value (converted to a string)
+// or the (optional) child "default" if there is no such child.
+func Switch(switchDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) {
+	group, _ := switchDescr[ui.AttrGroup].(string)
+	ref, ok := switchDescr["value"].(string) // value is required
+	if !ok {
+		log.Printf("ERROR: for %q: the value is missing", fullName)
+		return
+	}
+	children, ok := switchDescr[ui.AttrChildren].(ui.CommandsDescr)
+	if !ok {
+		log.Printf("ERROR: for %q: no cases found", fullName)
+		return
+	}
+	v, ok := ui.LookupValue(ref, group)
+	if !ok {
+		log.Printf("WARNING: for %q: no value found for %q", fullName, ref)
+	}
+	name := ui.ValueString(v)
+	if _, ok = children.Get(name); !ok {
+		name = ui.NameDefault
+	}
+	runChild(switchDescr, name, fullName, win, uiDescr)
+}
+
+// ValueLookup returns a function that looks up stored values
+// relative to the group for evaluating expressions.
+func ValueLookup(group string) expr.LookupFunc {
+	return func(ref string) (any, bool) {
+		return ui.LookupValue(ref, group)
+	}
+}
+
+// runChild runs the child with the name if it exists.
+func runChild(descr ui.AttributesDescr, name, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) {
+	children, ok := descr[ui.AttrChildren].(ui.CommandsDescr)
+	if !ok {
+		return
+	}
+	if child, ok := children.Get(name); ok {
+		Keyword(child, ui.FullNameFor(fullName, name), win, uiDescr)
+	}
+}
@@ -0,0 +1,68 @@
+package run
+
+import (
+	"log"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/flowdev/fdialog/ui"
+)
+
+// valueRefRegex matches references to stored values like `${key}` or `${group.key}`.
+var valueRefRegex = regexp.MustCompile(`\$\{([^}]+)}`)
+
+// Exec runs a command with arguments in the background.
+// References to stored values (`${key}` or `${group.key}`) in the command and
+// its arguments are replaced by the values.
+// Standard output is stored as value if an output key is given.
+// Depending on the exit status the child "success" or "failure" is run.
+func Exec(execDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) {
+	group, _ := execDescr[ui.AttrGroup].(string)
+	outputKey, _ := execDescr[ui.AttrOutputKey].(string)
+	id, _ := execDescr[ui.AttrID].(string)
+	command := expandValues(execDescr["command"].(string), group, fullName) // command is required
+	args := ui.AnysToStrings(execDescr["args"])
+	for i, arg := range args {
+		args[i] = expandValues(arg, group, fullName)
+	}
+
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		cmd := exec.Command(command, args...)
+		if dir, ok := execDescr["dir"].(string); ok {
+			cmd.Dir = ui.ResolvePath(dir)
+		}
+		cmd.Stderr = os.Stderr
+		out, err := cmd.Output()
+		name := ui.NameSuccess
+		if err != nil {
+			log.Printf("ERROR: for %q: command %q failed: %v", fullName, command, err)
+			name = ui.NameFailure
+		}
+		ui.Do(func() {
+			if outputKey != "" {
+				ui.StoreValue(strings.TrimRight(string(out), "\r\n"), outputKey, id, fullName, group)
+			}
+			runChild(execDescr, name, fullName, win, uiDescr)
+		})
+	}()
+}
+
+// expandValues replaces all references to stored values in s.
+// Unknown references are replaced by an empty string.
+func expandValues(s, group, fullName string) string {
+	return valueRefRegex.ReplaceAllStringFunc(s, func(ref string) string {
+		key := ref[2 : len(ref)-1]
+		v, ok := ui.LookupValue(key, group)
+		if !ok {
+			log.Printf("WARNING: for %q: no value found for reference %q", fullName, ref)
+			return ""
+		}
+		return ui.ValueString(v)
+	})
+}
@@ -0,0 +1,133 @@
+package run
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/flowdev/fdialog/ui"
+)
+
+const defaultHTTPTimeout = 30 * time.Second
+
+// HTTP sends a request in the background.
+// The values of the group are sent as JSON object in the body (except for GET requests).
+// References to stored values (`${key}` or `${group.key}`) in the URL and
+// the headers are replaced by the values.
+// Fields of the JSON response can be stored in the group.
+// Depending on the status of the response the child "success" or "failure" is run.
+func HTTP(httpDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) {
+	req, client, err := newHTTPRequest(httpDescr, fullName)
+
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		name := ui.NameFailure
+		if err != nil {
+			log.Printf("ERROR: for %q: unable to create HTTP request: %v", fullName, err)
+		} else if err = sendHTTPRequest(req, client, httpDescr, fullName); err != nil {
+			log.Printf("ERROR: for %q: HTTP request failed: %v", fullName, err)
+		} else {
+			name = ui.NameSuccess
+		}
+		ui.Do(func() {
+			runChild(httpDescr, name, fullName, win, uiDescr)
+		})
+	}()
+}
+
+// newHTTPRequest creates the request and the client (for the timeout).
+// The values are read immediately, so later changes don't influence the request.
+func newHTTPRequest(httpDescr ui.AttributesDescr, fullName string) (*http.Request, *http.Client, error) {
+	group, _ := httpDescr[ui.AttrGroup].(string)
+	url := expandValues(httpDescr["url"].(string), group, fullName) // url is required
+	method := http.MethodPost
+	if m, ok := httpDescr["method"].(string); ok {
+		method = m
+	}
+	timeout := defaultHTTPTimeout
+	if t, ok := httpDescr["timeout"].(float64); ok {
+		timeout = time.Duration(t * float64(time.Second))
+	}
+
+	var body io.Reader
+	_, hasGroup := httpDescr[ui.AttrGroup]
+	if hasGroup && method != http.MethodGet {
+		m, _ := ui.GetValueGroup(group)
+		body = bytes.NewReader(marshalMap(m, fullName))
+	}
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	for _, header := range ui.AnysToStrings(httpDescr["headers"]) {
+		key, value, _ := strings.Cut(header, ":") // validated already
+		req.Header.Set(strings.TrimSpace(key), strings.TrimSpace(expandValues(value, group, fullName)))
+	}
+	return req, &http.Client{Timeout: timeout}, nil
+}
+
+// sendHTTPRequest sends the request and stores the fields of the response.
+// Only a status of 2xx is successful.
+func sendHTTPRequest(req *http.Request, client *http.Client, httpDescr ui.AttributesDescr, fullName string) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s %s: unexpected status: %s", req.Method, req.URL, resp.Status)
+	}
+
+	fields := ui.AnysToStrings(httpDescr["store"])
+	if len(fields) == 0 {
+		return nil
+	}
+	var response map[string]any
+	if err = json.Unmarshal(data, &response); err != nil {
+		return fmt.Errorf("unable to decode JSON response: %w", err)
+	}
+	group, _ := httpDescr[ui.AttrGroup].(string)
+	ui.Do(func() {
+		for _, field := range fields {
+			v, ok := responseField(response, field)
+			if !ok {
+				log.Printf("WARNING: for %q: field %q not found in HTTP response", fullName, field)
+				continue
+			}
+			ui.StoreValue(v, field, "", fullName, group)
+		}
+	})
+	return nil
+}
+
+// responseField returns the field of a JSON object.
+// The names of nested fields are separated by dots (e.g. "user.id").
+func responseField(response map[string]any, field string) (any, bool) {
+	var v any = response
+	for _, key := range ui.SplitName(field) {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
@@ -0,0 +1,158 @@
+package run
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/flowdev/fdialog/ui"
+)
+
+func TestHTTP(t *testing.T) {
+	type request struct {
+		method string
+		path   string
+		header string
+		body   map[string]any
+	}
+	tests := []struct {
+		name        string
+		givenDescr  ui.AttributesDescr
+		givenReply  string
+		givenCode   int
+		givenDelay  time.Duration
+		givenValues map[string]any
+		wantReq     request
+		wantValues  map[string]any
+		wantMissing []string
+		wantErr     bool
+	}{
+		{
+			name: "post-group",
+			givenDescr: ui.AttributesDescr{
+				"url":     "/users",
+				"group":   "g",
+				"headers": []any{"X-Token: secret-${token}"},
+				"store":   []any{"id", "user.role"},
+			},
+			givenReply: `{"id": 7, "user": {"role": "admin"}}`,
+			givenCode:  http.StatusCreated,
+			wantReq: request{
+				method: http.MethodPost,
+				path:   "/users",
+				header: "secret-abc",
+				body:   map[string]any{"name": "Jane", "token": "abc", "address": map[string]any{"city": "Hamburg"}},
+			},
+			wantValues: map[string]any{
+				"name": "Jane", "token": "abc", "address.city": "Hamburg",
+				"id": float64(7), "user.role": "admin",
+			},
+		}, {
+			name: "get-with-reference",
+			givenDescr: ui.AttributesDescr{
+				"url":    "/users/${name}",
+				"method": "GET",
+				"group":  "g",
+				"store":  []any{"missing"},
+			},
+			givenReply:  `{}`,
+			givenCode:   http.StatusOK,
+			wantReq:     request{method: http.MethodGet, path: "/users/Jane"},
+			wantMissing: []string{"missing"},
+		}, {
+			name: "keep-stored-value",
+			givenDescr: ui.AttributesDescr{
+				"url":    "/users/${name}",
+				"method": "GET",
+				"group":  "g",
+				"store":  []any{"id", "role"},
+			},
+			givenReply:  `{"id": 7}`,
+			givenCode:   http.StatusOK,
+			givenValues: map[string]any{"role": "user"},
+			wantReq:     request{method: http.MethodGet, path: "/users/Jane"},
+			wantValues:  map[string]any{"id": float64(7), "role": "user"},
+		}, {
+			name:       "server-error",
+			givenDescr: ui.AttributesDescr{"url": "/users", "group": "g"},
+			givenCode:  http.StatusInternalServerError,
+			wantReq: request{
+				method: http.MethodPost,
+				path:   "/users",
+				body:   map[string]any{"name": "Jane", "token": "abc", "address": map[string]any{"city": "Hamburg"}},
+			},
+			wantErr: true,
+		}, {
+			name:       "timeout",
+			givenDescr: ui.AttributesDescr{"url": "/slow", "method": "DELETE", "timeout": 0.05},
+			givenCode:  http.StatusOK,
+			givenDelay: 500 * time.Millisecond,
+			wantReq:    request{method: http.MethodDelete, path: "/slow"},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ui.DeleteAllValues()
+			ui.StoreValue("Jane", "name", "", "main.form.name", "g")
+			ui.StoreValue("abc", "token", "", "main.form.token", "g")
+			ui.StoreValue("Hamburg", "address.city", "", "main.form.city", "g")
+			for k, v := range tt.givenValues {
+				ui.StoreValue(v, k, "", "main.form."+k, "g")
+			}
+
+			reqs := make(chan request, 1) // the handler may still run after a timeout
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				req := request{method: r.Method, path: r.URL.Path, header: r.Header.Get("X-Token")}
+				data, _ := io.ReadAll(r.Body)
+				if len(data) > 0 {
+					if err := json.Unmarshal(data, &req.body); err != nil {
+						t.Errorf("unable to decode request body %q: %v", data, err)
+					}
+				}
+				reqs <- req
+				time.Sleep(tt.givenDelay)
+				w.WriteHeader(tt.givenCode)
+				_, _ = io.WriteString(w, tt.givenReply)
+			}))
+			defer server.Close()
+
+			descr := ui.AttributesDescr{}
+			for k, v := range tt.givenDescr {
+				descr[k] = v
+			}
+			descr["url"] = server.URL + descr["url"].(string)
+
+			req, client, err := newHTTPRequest(descr, tt.name)
+			if err != nil {
+				t.Fatalf("unable to create request: %v", err)
+			}
+			err = sendHTTPRequest(req, client, descr, tt.name)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected an error but got none")
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if gotReq := <-reqs; !reflect.DeepEqual(gotReq, tt.wantReq) {
+				t.Errorf("request got %#v, want %#v", gotReq, tt.wantReq)
+			}
+			gotValues, _ := ui.GetValueGroup("g")
+			for k, want := range tt.wantValues {
+				if got, ok := gotValues[k]; !ok || !reflect.DeepEqual(got, want) {
+					t.Errorf("value %q got %#v, want %#v", k, got, want)
+				}
+			}
+			for _, k := range tt.wantMissing {
+				if got, ok := gotValues[k]; ok {
+					t.Errorf("value %q got %#v, want none", k, got)
+				}
+			}
+		})
+	}
+}
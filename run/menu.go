@@ -0,0 +1,99 @@
+package run
+
+import (
+	"log"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+
+	"github.com/flowdev/fdialog/ui"
+)
+
+// TypeSeparator is the type of menu items that separate other menu items.
+const TypeSeparator = "separator"
+
+// Menu adds a menu to the main menu of the window.
+// Its children are menu items, separators and nested menus.
+// The keyboard shortcuts of the menu items are added to the window.
+func Menu(menuDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) {
+	if win == nil { // top level menu without window
+		log.Printf("ERROR: for %q: unable to add a menu without window", fullName)
+		return
+	}
+	menu := fyne.NewMenu(labelFor(menuDescr), menuItems(menuDescr, fullName, win, uiDescr)...)
+	mainMenu := win.MainMenu()
+	if mainMenu == nil {
+		mainMenu = fyne.NewMainMenu()
+	}
+	mainMenu.Items = append(mainMenu.Items, menu)
+	win.SetMainMenu(mainMenu)
+}
+
+// menuItems creates the items of a menu with nested menus as child menus.
+func menuItems(menuDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) []*fyne.MenuItem {
+	children := menuDescr[ui.AttrChildren].(ui.CommandsDescr)
+	items := make([]*fyne.MenuItem, 0, children.Len())
+	for name, child := range children.All() {
+		childName := ui.FullNameFor(fullName, name)
+		switch {
+		case child[ui.AttrKeyword] == ui.KeywordMenu:
+			item := fyne.NewMenuItem(labelFor(child), nil)
+			item.ChildMenu = fyne.NewMenu("", menuItems(child, childName, win, uiDescr)...)
+			items = append(items, item)
+		case child[ui.AttrType] == TypeSeparator:
+			items = append(items, fyne.NewMenuItemSeparator())
+		default:
+			items = append(items, menuItem(child, childName, win, uiDescr))
+		}
+	}
+	return items
+}
+
+// menuItem creates a menu item that runs its children (actions or links).
+func menuItem(itemDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) *fyne.MenuItem {
+	item := fyne.NewMenuItem(labelFor(itemDescr), func() {
+		Children(itemDescr[ui.AttrChildren], fullName, win, uiDescr)
+	})
+	if icon, ok := itemDescr["icon"].(string); ok {
+		item.Icon = theme.Icon(fyne.ThemeIconName(icon))
+	}
+	item.Disabled, _ = itemDescr["disabled"].(bool)
+
+	if keys, ok := itemDescr["shortcut"].(string); ok {
+		shortcut, err := ui.ParseShortcut(keys)
+		if err != nil {
+			log.Printf("ERROR: for %q: %v", fullName, err)
+			return item
+		}
+		item.Shortcut = shortcut // shown in the menu
+		_, err = ui.AddShortcut(win, keys, func() {
+			if !item.Disabled {
+				item.Action()
+			}
+		})
+		if err != nil {
+			log.Printf("ERROR: for %q: %v", fullName, err)
+		}
+	}
+	return item
+}
+
+// Shortcut binds a key combination to its children (actions or links) in the window.
+func Shortcut(shortcutDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) {
+	keys := shortcutDescr["keys"].(string) // keys are required
+	_, err := ui.AddShortcut(win, keys, func() {
+		Children(shortcutDescr[ui.AttrChildren], fullName, win, uiDescr)
+	})
+	if err != nil {
+		log.Printf("ERROR: for %q: %v", fullName, err)
+	}
+}
+
+// labelFor returns the label of a menu or menu item (default: its name).
+func labelFor(descr ui.AttributesDescr) string {
+	if label, ok := descr["label"].(string); ok {
+		return label
+	}
+	label, _ := descr[ui.AttrName].(string)
+	return label
+}
@@ -0,0 +1,61 @@
+package run
+
+import (
+	"log"
+	"net/url"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+
+	"github.com/flowdev/fdialog/ui"
+)
+
+// opener opens the URLs of the open action.
+// The default (nil) is the app (e.g. the browser or the default application for a file).
+var opener func(u *url.URL) error
+
+// SetOpener sets the function that opens the URLs of the open action (e.g. for tests).
+// A nil function restores the default: the app opens them.
+func SetOpener(f func(u *url.URL) error) {
+	opener = f
+}
+
+// Open opens the URL or the local file with the default application.
+// References to stored values (`${key}` or `${group.key}`) in the file are
+// replaced by the values like in the exec action.
+func Open(openDescr ui.AttributesDescr, fullName string, _ fyne.Window, _ ui.CommandsDescr) {
+	surl, ok := openDescr["url"].(string)
+	if !ok { // one of both is required
+		group, _ := openDescr[ui.AttrGroup].(string)
+		surl = fileURL(expandValues(openDescr["file"].(string), group, fullName), fullName)
+	}
+	u, err := url.Parse(surl)
+	if err != nil {
+		log.Printf("ERROR: for %q: unable to parse URL: %v", fullName, err)
+		return
+	}
+
+	if opener != nil {
+		err = opener(u)
+	} else if app := ui.App(); app != nil {
+		err = app.OpenURL(u)
+	} else {
+		log.Printf("ERROR: for %q: unable to open %q without app", fullName, surl)
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR: for %q: unable to open %q: %v", fullName, surl, err)
+	}
+}
+
+// fileURL returns the file URL for a path relative to the directory of
+// the UI description file.
+func fileURL(path, fullName string) string {
+	absPath, err := filepath.Abs(ui.ResolvePath(path))
+	if err != nil {
+		log.Printf("WARNING: for %q: unable to make path %q absolute: %v", fullName, path, err)
+		absPath = path
+	}
+	return storage.NewFileURI(absPath).String()
+}
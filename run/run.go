@@ -3,28 +3,86 @@ package run
 import (
 	"fyne.io/fyne/v2"
 	"github.com/valyala/fastjson"
+	"io"
 	"log"
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
 
 	"github.com/flowdev/fdialog/ui"
 )
 
+const defaultAppID = "org.flowdev.fdialog"
+
 var jsonArena = &fastjson.ArenaPool{}
 
+// background counts the actions running in the background (e.g. exec and http),
+// so top level actions can wait for them.
+var background sync.WaitGroup
+
+// WaitForBackground blocks until all actions running in the background
+// (e.g. exec and http) and their children are finished.
+func WaitForBackground() {
+	background.Wait()
+}
+
+// output is the destination of the write action.
+// The default (nil) is standard output.
+var output io.Writer
+
+// SetOutput sets the destination of the write action.
+// A nil writer restores the default: standard output.
+func SetOutput(w io.Writer) {
+	output = w
+}
+
+// input is the source of progress dialogs.
+// The default (nil) is standard input.
+var input io.Reader
+
+// SetInput sets the source of progress dialogs.
+// A nil reader restores the default: standard input.
+func SetInput(r io.Reader) {
+	input = r
+}
+
+// notifier is the destination of the notify action.
+// The default (nil) is the notification system of the desktop.
+var notifier func(n *fyne.Notification)
+
+// SetNotifier sets the destination of the notify action (e.g. for tests).
+// A nil function restores the default: the notification system of the desktop.
+func SetNotifier(f func(n *fyne.Notification)) {
+	notifier = f
+}
+
+// Input returns the source of progress dialogs.
+func Input() io.Reader {
+	if input == nil {
+		return os.Stdin
+	}
+	return input
+}
+
 // UIDescription runs a whole UI description and returns any error encountered.
+// A UI description without main window can contain only actions (e.g. notifications).
 func UIDescription(uiDescr ui.CommandsDescr) {
 	mainWin, ok := uiDescr.Get(ui.WinMain)
 	if !ok {
-		log.Printf("FATAL: unable to find main window in UI description")
+		if !OnlyActions(uiDescr) {
+			log.Printf("FATAL: unable to find main window in UI description")
+			return
+		}
+		ui.NewApp(defaultAppID)
+		TopLevelActions(uiDescr)
 		return
 	}
 	if mainWin[ui.AttrKeyword] != ui.KeywordWindow {
 		log.Printf(`command with name 'main' is not a window but a:  %q`, mainWin[ui.AttrKeyword])
 	}
-	appID := "org.flowdev.fdialog"
+	appID := defaultAppID
 	if aid, ok := mainWin["appId"]; ok {
 		appID = aid.(string)
 	}
@@ -40,6 +98,34 @@ func UIDescription(uiDescr ui.CommandsDescr) {
 	ui.RunApp()
 }
 
+// OnlyActions returns true if the UI description consists only of actions.
+func OnlyActions(uiDescr ui.CommandsDescr) bool {
+	for _, attrs := range uiDescr.All() {
+		if attrs[ui.AttrKeyword] != ui.KeywordAction {
+			return false
+		}
+	}
+	return uiDescr.Len() > 0
+}
+
+// TopLevelActions runs all actions of a UI description without main window
+// and exits the app afterward.
+// Actions running in the background (e.g. exec) are waited for,
+// so their children run, too.
+func TopLevelActions(uiDescr ui.CommandsDescr) {
+	ui.Do(func() {
+		for name, attrs := range uiDescr.All() {
+			Keyword(attrs, name, nil, uiDescr)
+		}
+	})
+	background.Wait()
+	ui.Do(func() {
+		if ui.App() != nil { // an exit action might have exited already
+			ui.ExitApp(-1)
+		}
+	})
+}
+
 // ---------------------------------------------------------------------------
 // Keywords
 //
@@ -63,6 +149,7 @@ func Window(winDescr ui.AttributesDescr, fullName string, _ fyne.Window, uiDescr
 		win.SetFixedSize(true)
 	}
 
+	stopTimeout := func() {}
 	if fullName == ui.WinMain {
 		if code, ok := winDescr["exitCode"]; ok { // set the correct exit code
 			ui.StoreExitCode(int32(code.(int64)))
@@ -70,6 +157,7 @@ func Window(winDescr ui.AttributesDescr, fullName string, _ fyne.Window, uiDescr
 
 		// Exit the app nicely with the correct exit code ...
 		interceptor := func() {
+			stopTimeout()
 			win.Close()
 			ui.ExitApp(-1)
 		}
@@ -81,12 +169,12 @@ func Window(winDescr ui.AttributesDescr, fullName string, _ fyne.Window, uiDescr
 		go func() {
 			// Block until a signal is received.
 			_ = <-signalChan
-			interceptor()
+			ui.Do(interceptor)
 		}()
 	}
 
 	if children, ok := winDescr[ui.AttrChildren]; ok {
-		Children(children, fullName, win, uiDescr)
+		WindowChildren(children, fullName, win, uiDescr)
 	}
 
 	win.SetTitle(title)
@@ -95,6 +183,22 @@ func Window(winDescr ui.AttributesDescr, fullName string, _ fyne.Window, uiDescr
 		winSize = fyne.NewSize(width, height)
 		win.Resize(winSize)
 	}
+	stopTimeout = Timeout(winDescr, fullName, win, uiDescr, nil)
+	if fullName != ui.WinMain {
+		win.SetOnClosed(stopTimeout)
+	}
+}
+
+// WindowChildren runs all children of a window except the timeout action.
+func WindowChildren(achildren any, parent string, win fyne.Window, uiDescr ui.CommandsDescr) {
+	childDescr := achildren.(ui.CommandsDescr) // type validation has happened already :)
+
+	for name, keywordDescr := range childDescr.All() {
+		if name == ui.NameTimeout { // run by Timeout
+			continue
+		}
+		Keyword(keywordDescr, ui.FullNameFor(parent, name), win, uiDescr)
+	}
 }
 
 func Children(achildren any, parent string, win fyne.Window, uiDescr ui.CommandsDescr) {
@@ -170,6 +274,9 @@ func Exit(exitDescr ui.AttributesDescr, _ string, _ fyne.Window, _ ui.CommandsDe
 }
 
 func Close(_ ui.AttributesDescr, _ string, win fyne.Window, _ ui.CommandsDescr) {
+	if win == nil { // top level action without window
+		return
+	}
 	win.Close()
 }
 
@@ -184,6 +291,22 @@ func Group(groupDescr ui.AttributesDescr, parent string, win fyne.Window, uiDesc
 	}
 }
 
+func Notify(notifyDescr ui.AttributesDescr, fullName string, _ fyne.Window, _ ui.CommandsDescr) {
+	title, _ := notifyDescr["title"].(string)  // title is optional with zero value as default
+	content := notifyDescr["content"].(string) // content is required
+	n := fyne.NewNotification(title, content)
+	if notifier != nil {
+		notifier(n)
+		return
+	}
+	app := ui.App()
+	if app == nil {
+		log.Printf("ERROR: for %q: unable to send notification without app", fullName)
+		return
+	}
+	app.SendNotification(n)
+}
+
 func Write(writeDescr ui.AttributesDescr, fullName string, _ fyne.Window, _ ui.CommandsDescr) {
 	group, gok := writeDescr[ui.AttrGroup].(string)
 	id, iok := writeDescr[ui.AttrID].(string)
@@ -216,18 +339,26 @@ func Write(writeDescr ui.AttributesDescr, fullName string, _ fyne.Window, _ ui.C
 	}
 }
 func writeMap(m map[string]any, fullName string) {
+	out := output
+	if out == nil {
+		out = os.Stdout
+	}
+	_, err := out.Write(append(marshalMap(m, fullName), '\n'))
+	if err != nil {
+		log.Printf(`ERROR: for %q: unable to write to output: %v`, fullName, err)
+	}
+}
+
+// marshalMap converts a map of values into a JSON object.
+// Keys with dots are converted into nested objects.
+func marshalMap(m map[string]any, fullName string) []byte {
 	m = normalizeMap(m, fullName)
 	arena := jsonArena.Get()
 	defer func() {
 		arena.Reset()
 		jsonArena.Put(arena)
 	}()
-	val := writeJSONMap(m, arena, fullName)
-	_, err := os.Stdout.Write(val.MarshalTo(nil))
-	if err != nil {
-		log.Printf(`ERROR: for %q: unable to write to STDOUT: %v`, fullName, err)
-	}
-	_, _ = os.Stdout.WriteString("\n")
+	return writeJSONMap(m, arena, fullName).MarshalTo(nil)
 }
 func normalizeMap(m map[string]any, fullName string) map[string]any {
 	m2 := make(map[string]any, len(m))
@@ -272,6 +403,8 @@ func writeJSONMap(m map[string]any, arena *fastjson.Arena, fullName string) *fas
 }
 func writeJSONValue(value any, arena *fastjson.Arena, fullName string) *fastjson.Value {
 	switch v := value.(type) {
+	case nil:
+		return arena.NewNull()
 	case string:
 		return arena.NewString(v)
 	case int8:
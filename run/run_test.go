@@ -153,3 +153,62 @@ func fileContent(t *testing.T, f *os.File) map[string]any {
 	}
 	return result
 }
+
+func TestExpandValues(t *testing.T) {
+	ui.DeleteAllValues()
+	ui.DeleteAllIDs()
+	ui.StoreValue("example.com", "host", "", "main.form.host", "conn")
+	ui.StoreValue(float64(8080), "port", "", "main.form.port", "conn")
+	ui.StoreValue([]any{"a", "b"}, "tags", "", "main.form.tags", "conn")
+	ui.StoreValue(true, "", "", "main.other.verbose", "other")
+	_ = ui.RegisterID("verbose", "main.other.verbose")
+
+	tests := []struct {
+		name      string
+		givenArg  string
+		givenGrp  string
+		wantValue string
+	}{
+		{name: "no-reference", givenArg: "-c", givenGrp: "conn", wantValue: "-c"},
+		{name: "key", givenArg: "${host}", givenGrp: "conn", wantValue: "example.com"},
+		{name: "float", givenArg: "${host}:${port}", givenGrp: "conn", wantValue: "example.com:8080"},
+		{name: "list", givenArg: "--tags=${tags}", givenGrp: "conn", wantValue: "--tags=a,b"},
+		{name: "group-and-key", givenArg: "${conn.host}", givenGrp: "", wantValue: "example.com"},
+		{name: "id", givenArg: "${other.verbose}", givenGrp: "conn", wantValue: "true"},
+		{name: "unknown", givenArg: "x${nothing}y", givenGrp: "conn", wantValue: "xy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := expandValues(tt.givenArg, tt.givenGrp, tt.name)
+			if got != tt.wantValue {
+				t.Errorf("got %q, want %q", got, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestLiteralOrSource(t *testing.T) {
+	ui.DeleteAllValues()
+	ui.StoreValue("Jane", "name", "", "main.form.name", "form")
+
+	tests := []struct {
+		name       string
+		givenDescr ui.AttributesDescr
+		wantValue  any
+		wantOK     bool
+	}{
+		{name: "literal", givenDescr: ui.AttributesDescr{"value": "x"}, wantValue: "x", wantOK: true},
+		{name: "source", givenDescr: ui.AttributesDescr{"source": "form.name"}, wantValue: "Jane", wantOK: true},
+		{name: "unknown-source", givenDescr: ui.AttributesDescr{"source": "form.age"}},
+		{name: "missing-source", givenDescr: ui.AttributesDescr{}},
+		{name: "wrong-type", givenDescr: ui.AttributesDescr{"source": 42.0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := literalOrSource(tt.givenDescr, "value", tt.name)
+			if ok != tt.wantOK || got != tt.wantValue {
+				t.Errorf("got (%#v, %t), want (%#v, %t)", got, ok, tt.wantValue, tt.wantOK)
+			}
+		})
+	}
+}
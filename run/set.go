@@ -0,0 +1,66 @@
+package run
+
+import (
+	"log"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/flowdev/fdialog/ui"
+)
+
+// Set assigns the literal value or the stored value referenced by source
+// to the item referenced by target (an ID or a full name).
+// The widget of the item shows the new value immediately.
+func Set(setDescr ui.AttributesDescr, fullName string, _ fyne.Window, _ ui.CommandsDescr) {
+	target, ok := setDescr["target"].(string) // target is required
+	if !ok {
+		log.Printf("ERROR: for %q: the target is missing", fullName)
+		return
+	}
+	targetName, ok := ui.FullNameForID(target)
+	if !ok {
+		targetName = target
+	}
+	v, ok := literalOrSource(setDescr, "value", fullName)
+	if !ok {
+		return
+	}
+	if err := ui.SetBoundValue(v, targetName); err != nil {
+		log.Printf("ERROR: for %q: unable to set value of %q: %v", fullName, target, err)
+	}
+}
+
+// Clipboard copies the text or the stored value referenced by source
+// to the clipboard.
+func Clipboard(clipDescr ui.AttributesDescr, fullName string, win fyne.Window, _ ui.CommandsDescr) {
+	v, ok := literalOrSource(clipDescr, "text", fullName)
+	if !ok {
+		return
+	}
+	if win == nil { // top level action without window
+		log.Printf("ERROR: for %q: unable to copy to the clipboard without window", fullName)
+		return
+	}
+	win.Clipboard().SetContent(ui.ValueString(v))
+}
+
+// literalOrSource returns the value of the attribute with the literal or
+// the stored value referenced by the attribute "source".
+// References are looked up in the group of the action.
+func literalOrSource(descr ui.AttributesDescr, literal, fullName string) (any, bool) {
+	if v, ok := descr[literal]; ok {
+		return v, true
+	}
+	group, _ := descr[ui.AttrGroup].(string)
+	ref, ok := descr["source"].(string) // one of both is required
+	if !ok {
+		log.Printf("ERROR: for %q: neither %q nor %q is given", fullName, literal, "source")
+		return nil, false
+	}
+	v, ok := ui.LookupValue(ref, group)
+	if !ok {
+		log.Printf("WARNING: for %q: no value found for %q", fullName, ref)
+		return nil, false
+	}
+	return v, true
+}
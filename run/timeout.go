@@ -0,0 +1,102 @@
+package run
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/flowdev/fdialog/ui"
+)
+
+var timeoutsDisabled bool // a preview must not exit because of a timeout
+
+// DisableTimeouts disables (or enables again) the timeouts of all windows and dialogs.
+func DisableTimeouts(disabled bool) {
+	timeoutsDisabled = disabled
+}
+
+// Timeout starts the timeout of a window or dialog if it has the attribute "timeout".
+// The returned function stops the timeout (e.g. when the dialog has been closed).
+// When it expires, dismiss is called (if not nil) and the "timeout" child is run.
+// Without a "timeout" child the app exits with ui.ExitCodeTimeout.
+// With the attribute "countdown" the remaining seconds are shown in the title of the window.
+func Timeout(
+	descr ui.AttributesDescr,
+	fullName string,
+	win fyne.Window,
+	uiDescr ui.CommandsDescr,
+	dismiss func(),
+) (stop func()) {
+	seconds, ok := descr["timeout"].(float64)
+	if !ok || timeoutsDisabled {
+		return func() {}
+	}
+	countdown, _ := descr["countdown"].(bool)
+	var keyTimeout ui.AttributesDescr
+	if children, ok := descr[ui.AttrChildren].(ui.CommandsDescr); ok {
+		keyTimeout, _ = children.Get(ui.NameTimeout)
+	}
+	app := ui.App()
+	if app == nil {
+		return func() {}
+	}
+	d := time.Duration(seconds * float64(time.Second))
+
+	done := make(chan struct{})
+	var once sync.Once
+	finish := func() bool { // returns true only for the first call
+		first := false
+		once.Do(func() {
+			close(done)
+			first = true
+		})
+		return first
+	}
+	if countdown {
+		go showCountdown(win, d, done)
+	}
+
+	timer := time.AfterFunc(d, func() {
+		ui.Do(func() {
+			if !finish() || ui.App() != app {
+				return
+			}
+			if dismiss != nil {
+				dismiss()
+			}
+			if keyTimeout == nil {
+				ui.ExitApp(ui.ExitCodeTimeout)
+				return
+			}
+			Keyword(keyTimeout, ui.FullNameFor(fullName, ui.NameTimeout), win, uiDescr)
+		})
+	})
+	return func() {
+		if finish() {
+			timer.Stop()
+		}
+	}
+}
+
+// showCountdown shows the remaining seconds in the title of the window
+// every second until done is closed.
+func showCountdown(win fyne.Window, d time.Duration, done <-chan struct{}) {
+	var title string
+	ui.Do(func() { title = win.Title() })
+	deadline := time.Now().Add(d)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		sec := int(math.Ceil(time.Until(deadline).Seconds()))
+		ui.Do(func() { win.SetTitle(fmt.Sprintf("%s (%d)", title, max(sec, 0))) })
+		select {
+		case <-done:
+			ui.Do(func() { win.SetTitle(title) })
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// Package screenshot renders UI descriptions without a display.
+// It uses the software test driver of Fyne and captures the canvas of the
+// main window including all dialogs shown on top of it.
+package screenshot
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+
+	"github.com/flowdev/fdialog/ui"
+)
+
+// Supported themes:
+const (
+	ThemeLight = "light"
+	ThemeDark  = "dark"
+)
+
+// variantTheme is the default theme of Fyne fixed to a single variant.
+type variantTheme struct {
+	fyne.Theme
+	variant fyne.ThemeVariant
+}
+
+func (t variantTheme) Color(name fyne.ThemeColorName, _ fyne.ThemeVariant) color.Color {
+	return t.Theme.Color(name, t.variant)
+}
+
+// Capture runs the main window of a validated UI description with the
+// software test driver of Fyne and returns an image of its canvas.
+// If width and height are positive, they override the size of the window.
+func Capture(uiDescr ui.CommandsDescr, width, height float32, themeName string) (image.Image, error) {
+	var variant fyne.ThemeVariant
+	switch themeName {
+	case ThemeLight:
+		variant = theme.VariantLight
+	case ThemeDark:
+		variant = theme.VariantDark
+	default:
+		return nil, fmt.Errorf("unknown theme %q (valid values are: %q or %q)", themeName, ThemeLight, ThemeDark)
+	}
+
+	mainWin, ok := uiDescr.Get(ui.WinMain)
+	if !ok {
+		return nil, errors.New("unable to find main window in UI description")
+	}
+	if mainWin[ui.AttrKeyword] != ui.KeywordWindow {
+		return nil, fmt.Errorf("command with name 'main' is not a window but a: %q", mainWin[ui.AttrKeyword])
+	}
+	runWin, ok := ui.RunFuncForKeyword(ui.KeywordWindow)
+	if !ok {
+		return nil, errors.New("unable to get run function for keyword 'window'")
+	}
+
+	testApp := test.NewApp()
+	applyTheme(testApp, variantTheme{Theme: theme.DefaultTheme(), variant: variant})
+	ui.SetApp(testApp)
+	defer ui.SetApp(nil)
+
+	runWin(mainWin, ui.WinMain, nil, uiDescr)
+
+	windows := testApp.Driver().AllWindows()
+	if len(windows) < 2 { // the test driver always creates a dummy window
+		return nil, errors.New("no window has been created")
+	}
+	win := windows[len(windows)-1]
+	defer func() {
+		win.SetOnClosed(nil) // don't exit
+		win.Close()
+	}()
+
+	if width > 0 && height > 0 {
+		win.Resize(fyne.NewSize(width, height))
+	} else if win.Canvas().Size().IsZero() {
+		win.Resize(minSize(win.Canvas()))
+	}
+	refresh(win.Canvas()) // no widget should keep colors of the default theme
+	return win.Canvas().Capture(), nil
+}
+
+// WritePNG writes an image to a PNG file.
+func WritePNG(img image.Image, fileName string) error {
+	f, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	err = png.Encode(f, img)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// MatchesPNG compares an image pixel by pixel with a golden PNG file.
+// It returns `false` if they differ.
+func MatchesPNG(img image.Image, goldenFile string) (bool, error) {
+	f, err := os.Open(goldenFile)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	golden, err := png.Decode(f)
+	if err != nil {
+		return false, fmt.Errorf("unable to decode golden image %q: %w", goldenFile, err)
+	}
+	return imagesEqual(img, golden), nil
+}
+
+func imagesEqual(img1, img2 image.Image) bool {
+	bounds := img1.Bounds()
+	if bounds.Size() != img2.Bounds().Size() {
+		return false
+	}
+	off := img2.Bounds().Min.Sub(bounds.Min)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c1 := color.NRGBAModel.Convert(img1.At(x, y))
+			c2 := color.NRGBAModel.Convert(img2.At(x+off.X, y+off.Y))
+			if c1 != c2 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// applyTheme sets the theme of the test app and waits until it is applied.
+// The test app applies it in its own goroutine by refreshing the content
+// of all windows, so a window with a refreshSignal as content tells when
+// this is done.
+func applyTheme(a fyne.App, th fyne.Theme) {
+	win := a.NewWindow("")
+	signal := &refreshSignal{}
+	win.SetContent(signal)
+	signal.refreshed = make(chan struct{})
+	a.Settings().SetTheme(th)
+	<-signal.refreshed
+	win.Close()
+}
+
+// refreshSignal closes the channel refreshed when it is refreshed the first time
+// after the channel has been set.
+type refreshSignal struct {
+	canvas.Rectangle
+	once      sync.Once
+	refreshed chan struct{}
+}
+
+func (s *refreshSignal) Refresh() {
+	if s.refreshed != nil {
+		s.once.Do(func() { close(s.refreshed) })
+	}
+}
+
+// refresh refreshes the content and all overlays (e.g. dialogs) of the canvas.
+func refresh(c fyne.Canvas) {
+	if content := c.Content(); content != nil {
+		content.Refresh()
+	}
+	for _, overlay := range c.Overlays().List() {
+		overlay.Refresh()
+	}
+}
+
+// minSize returns a size big enough for the content and all overlays
+// (e.g. dialogs) of the canvas.
+func minSize(c fyne.Canvas) fyne.Size {
+	size := fyne.NewSize(0, 0)
+	if content := c.Content(); content != nil {
+		size = size.Max(content.MinSize())
+	}
+	for _, overlay := range c.Overlays().List() {
+		size = size.Max(overlay.MinSize())
+	}
+	return size.Add(fyne.NewSquareSize(2 * theme.Padding()))
+}
@@ -0,0 +1,133 @@
+package screenshot_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/flowdev/fdialog/parse"
+	"github.com/flowdev/fdialog/screenshot"
+	"github.com/flowdev/fdialog/ui"
+	"github.com/flowdev/fdialog/uimain"
+	"github.com/flowdev/fdialog/valid"
+)
+
+const infoUIDL = `uidl 1
+
+window main(title="Information", width=480, height=250) {
+    dialog info(type="info", message="Hello, screenshot!", buttonText="OK", width=480, height=250) {
+        action close(type="exit", code=0)
+    }
+}
+`
+
+func TestCapture(t *testing.T) {
+	_ = uimain.RegisterEverything()
+
+	specs := []struct {
+		name          string
+		givenTheme    string
+		givenWidth    float32
+		givenHeight   float32
+		expectedError bool
+		expectedX     int
+		expectedY     int
+	}{
+		{
+			name:       "light",
+			givenTheme: screenshot.ThemeLight,
+			expectedX:  480,
+			expectedY:  250,
+		}, {
+			name:       "dark",
+			givenTheme: screenshot.ThemeDark,
+			expectedX:  480,
+			expectedY:  250,
+		}, {
+			name:        "explicitSize",
+			givenTheme:  screenshot.ThemeLight,
+			givenWidth:  600,
+			givenHeight: 300,
+			expectedX:   600,
+			expectedY:   300,
+		}, {
+			name:          "unknownTheme",
+			givenTheme:    "purple",
+			expectedError: true,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			uiDescr := parseUIDL(t, infoUIDL)
+
+			img, err := screenshot.Capture(uiDescr, spec.givenWidth, spec.givenHeight, spec.givenTheme)
+			if spec.expectedError {
+				if err == nil {
+					t.Fatal("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			size := img.Bounds().Size()
+			if size.X != spec.expectedX || size.Y != spec.expectedY {
+				t.Errorf("expected image size %dx%d, got %dx%d",
+					spec.expectedX, spec.expectedY, size.X, size.Y)
+			}
+
+			golden := filepath.Join(t.TempDir(), "golden.png")
+			if err = screenshot.WritePNG(img, golden); err != nil {
+				t.Fatalf("unable to write PNG: %v", err)
+			}
+			img2, err := screenshot.Capture(parseUIDL(t, infoUIDL), spec.givenWidth, spec.givenHeight, spec.givenTheme)
+			if err != nil {
+				t.Fatalf("unexpected error for second capture: %v", err)
+			}
+			ok, err := screenshot.MatchesPNG(img2, golden)
+			if err != nil {
+				t.Fatalf("unable to compare with golden PNG: %v", err)
+			}
+			if !ok {
+				t.Error("expected the same image when rendering twice")
+			}
+		})
+	}
+}
+
+func TestCapture_themesDiffer(t *testing.T) {
+	_ = uimain.RegisterEverything()
+
+	light, err := screenshot.Capture(parseUIDL(t, infoUIDL), 0, 0, screenshot.ThemeLight)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	golden := filepath.Join(t.TempDir(), "light.png")
+	if err = screenshot.WritePNG(light, golden); err != nil {
+		t.Fatalf("unable to write PNG: %v", err)
+	}
+	dark, err := screenshot.Capture(parseUIDL(t, infoUIDL), 0, 0, screenshot.ThemeDark)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ok, err := screenshot.MatchesPNG(dark, golden)
+	if err != nil {
+		t.Fatalf("unable to compare with golden PNG: %v", err)
+	}
+	if ok {
+		t.Error("expected light and dark images to differ")
+	}
+}
+
+func parseUIDL(t *testing.T, input string) ui.CommandsDescr {
+	ui.DeleteAllIDs()
+	uiDescr, err := parse.UIDL(strings.NewReader(input), t.Name())
+	if err != nil {
+		t.Fatalf("unable to parse UIDL: %v", err)
+	}
+	if ok := valid.UIDescription(uiDescr, true); !ok {
+		t.Fatal("invalid UI description")
+	}
+	return uiDescr
+}
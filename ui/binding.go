@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// BindingValue returns the current value of a data binding of an input widget
+// as it is stored (e.g. in JSON output).
+// It returns nil for unsupported bindings.
+func BindingValue(b binding.DataItem) any {
+	var v any
+	var err error
+	switch b := b.(type) {
+	case binding.String:
+		v, err = b.Get()
+	case binding.Bool:
+		v, err = b.Get()
+	case binding.Float:
+		v, err = b.Get()
+	case binding.Untyped:
+		v, err = b.Get()
+	}
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+// SetBindingValue sets the value of a data binding of an input widget,
+// so the widget shows it.
+// The value is converted to the type of the binding if possible
+// (e.g. numbers to strings).
+func SetBindingValue(b binding.DataItem, v any) error {
+	switch b := b.(type) {
+	case binding.String:
+		return b.Set(ValueString(v))
+	case binding.Bool:
+		bv, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("expecting a boolean value, got %T", v)
+		}
+		return b.Set(bv)
+	case binding.Float:
+		switch f := v.(type) {
+		case float64:
+			return b.Set(f)
+		case int64:
+			return b.Set(float64(f))
+		}
+		return fmt.Errorf("expecting a number, got %T", v)
+	case binding.Untyped:
+		return b.Set(v)
+	}
+	return fmt.Errorf("unable to set a value of data binding %T", b)
+}
+
+// NewString returns a binding for strings.
+// In contrast to binding.NewString, the listeners are called synchronously,
+// so a widget bound to it only changes in the goroutine setting the value.
+func NewString() binding.String {
+	return &value[string]{}
+}
+
+// NewBool returns a binding for booleans with synchronous listeners
+// (see NewString).
+func NewBool() binding.Bool {
+	return &value[bool]{}
+}
+
+// NewFloat returns a binding for numbers with synchronous listeners
+// (see NewString).
+func NewFloat() binding.Float {
+	return &value[float64]{}
+}
+
+// value is a binding for comparable values.
+// Like the bindings of Fyne, the listeners are only notified of changes.
+type value[T comparable] struct {
+	listeners
+	lock  sync.RWMutex
+	value T
+}
+
+func (v *value[T]) Get() (T, error) {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	return v.value, nil
+}
+
+func (v *value[T]) Set(value T) error {
+	v.lock.Lock()
+	if v.value == value {
+		v.lock.Unlock()
+		return nil
+	}
+	v.value = value
+	v.lock.Unlock()
+
+	v.notify()
+	return nil
+}
+
+// NewUntyped returns a binding for values of any type (e.g. lists and objects).
+// In contrast to binding.NewUntyped, values aren't compared (this panics
+// for lists), so the listeners are notified of every change.
+// The listeners are called synchronously.
+func NewUntyped() binding.Untyped {
+	return &untyped{}
+}
+
+type untyped struct {
+	listeners
+	lock  sync.RWMutex
+	value any
+}
+
+func (u *untyped) Get() (any, error) {
+	u.lock.RLock()
+	defer u.lock.RUnlock()
+	return u.value, nil
+}
+
+func (u *untyped) Set(value any) error {
+	u.lock.Lock()
+	u.value = value
+	u.lock.Unlock()
+
+	u.notify()
+	return nil
+}
+
+// listeners calls the data listeners of a binding synchronously.
+// A new listener is called immediately (like in Fyne).
+type listeners struct {
+	lock sync.Mutex
+	list []binding.DataListener
+}
+
+func (l *listeners) AddListener(listener binding.DataListener) {
+	l.lock.Lock()
+	l.list = append(l.list, listener)
+	l.lock.Unlock()
+	listener.DataChanged()
+}
+
+func (l *listeners) RemoveListener(listener binding.DataListener) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.list = slices.DeleteFunc(l.list, func(dl binding.DataListener) bool {
+		return dl == listener
+	})
+}
+
+// notify calls all listeners.
+// They are copied first, so a listener can add or remove listeners.
+func (l *listeners) notify() {
+	l.lock.Lock()
+	list := slices.Clone(l.list)
+	l.lock.Unlock()
+
+	for _, listener := range list {
+		listener.DataChanged()
+	}
+}
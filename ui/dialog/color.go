@@ -10,9 +10,10 @@ import (
 	"log"
 )
 
-func runPickColor(colorDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) {
-	callback := run.BooleanCallback(colorDescr[ui.AttrChildren].(ui.CommandsDescr),
-		ui.NameChoose, ui.NameCancel, fullName, win, uiDescr)
+func runPickColor(colorDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) *dialogState {
+	state := newDialogState(win, fullName)
+	callback := state.closing(run.BooleanCallback(colorDescr[ui.AttrChildren].(ui.CommandsDescr),
+		ui.NameChoose, ui.NameCancel, fullName, win, uiDescr))
 	title, _ := colorDescr["title"].(string) // title is optional with zero value as default
 	outputKey, _ := colorDescr[ui.AttrOutputKey].(string)
 	id, _ := colorDescr[ui.AttrID].(string)
@@ -45,14 +46,13 @@ func runPickColor(colorDescr ui.AttributesDescr, fullName string, win fyne.Windo
 		picker.Resize(fyne.NewSize(width, height))
 	}
 
-	win.Canvas().SetOnTypedKey(func(keyEvent *fyne.KeyEvent) {
-		switch keyEvent.Name {
-		case fyne.KeyEscape:
-			callback(false)
-		}
-	})
+	state.addKeys(func() {
+		callback(false)
+		picker.Hide() // the callback isn't called again
+	}, "Escape")
 
 	picker.Show()
+	return state.shown(picker)
 }
 
 func colorToString(c color.Color) string {
@@ -0,0 +1,92 @@
+package dialog
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/flowdev/fdialog/run"
+	"github.com/flowdev/fdialog/ui"
+	uiwidget "github.com/flowdev/fdialog/ui/widget"
+	"log"
+)
+
+// ValidateCustomDialog checks that all children are buttons (except the
+// timeout action) and that the cancel button exists.
+func ValidateCustomDialog(attrs ui.AttributesDescr, parent string) bool {
+	children, ok := attrs[ui.AttrChildren].(ui.CommandsDescr)
+	if !ok {
+		return true // reported by the children validator
+	}
+	ok = true
+	for name, child := range children.All() {
+		if name == ui.NameTimeout && child[ui.AttrKeyword] == ui.KeywordAction {
+			continue
+		}
+		if child[ui.AttrKeyword] != KeywordButton {
+			log.Printf("ERROR: for %q: only buttons are allowed as children, got: %q",
+				ui.FullNameFor(parent, name), child[ui.AttrKeyword])
+			ok = false
+		}
+	}
+	if cancel, found := attrs["cancelButton"].(string); found {
+		if _, found = children.Get(cancel); !found {
+			log.Printf("ERROR: for %q: cancel button %q doesn't exist", parent, cancel)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// runCustom shows a dialog with a message and any number of buttons.
+// The name of the chosen button is stored as value and its children are run.
+func runCustom(customDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) *dialogState {
+	title, _ := customDescr["title"].(string)  // title is optional with zero value as default
+	message := customDescr["message"].(string) // message is required
+	outputKey, _ := customDescr[ui.AttrOutputKey].(string)
+	id, _ := customDescr[ui.AttrID].(string)
+	group, _ := customDescr[ui.AttrGroup].(string)
+	children := customDescr[ui.AttrChildren].(ui.CommandsDescr)
+
+	customDialog := dialog.NewCustomWithoutButtons(title, widget.NewLabel(message), win)
+	state := newDialogState(win, fullName)
+	customDialog.SetOnClosed(state.removeKeys)
+	callbacks := make(map[string]func(), children.Len())
+	buttons := make([]fyne.CanvasObject, 0, children.Len())
+	for name, buttonDescr := range children.All() {
+		if buttonDescr[ui.AttrKeyword] != KeywordButton {
+			continue // timeout action
+		}
+		buttonName := ui.FullNameFor(fullName, name)
+		callback := func() {
+			customDialog.Hide()
+			ui.StoreValue(name, outputKey, id, fullName, group)
+			if buttonChildren, ok := buttonDescr[ui.AttrChildren]; ok {
+				run.Children(buttonChildren, buttonName, win, uiDescr)
+			} else {
+				win.Close() // default
+			}
+		}
+		callbacks[name] = callback
+
+		text := name
+		if t, ok := buttonDescr["text"].(string); ok {
+			text = t
+		}
+		button := widget.NewButton(text, callback)
+		button.Importance = uiwidget.ImportanceFor(buttonDescr)
+		buttons = append(buttons, button)
+	}
+	customDialog.SetButtons(buttons)
+
+	width, height := run.GetSize(customDescr)
+	if width > 0 && height > 0 {
+		customDialog.Resize(fyne.NewSize(width, height))
+	}
+
+	if cancel, ok := customDescr["cancelButton"].(string); ok {
+		state.addKeys(callbacks[cancel], "Escape")
+	}
+
+	customDialog.Show()
+	return state.shown(customDialog)
+}
@@ -2,16 +2,17 @@ package dialog
 
 import (
 	"fyne.io/fyne/v2"
+	"github.com/flowdev/fdialog/run"
 	"github.com/flowdev/fdialog/ui"
+	uiwidget "github.com/flowdev/fdialog/ui/widget"
 	"github.com/flowdev/fdialog/valid"
 	"log"
 	"math"
-	"regexp"
+	"sync/atomic"
 )
 
 const KeywordDialog = "dialog"
-
-var extensionRegex = regexp.MustCompile(`^\..+$`)
+const KeywordButton = "button"
 
 func RegisterAll() error {
 	// -----------------------------------------------------------------------
@@ -44,9 +45,15 @@ func RegisterAll() error {
 			"height": {
 				Validate: valid.FloatValidator(80.0, math.MaxFloat32),
 			},
+			"timeout": {
+				Validate: valid.FloatValidator(0.1, math.MaxFloat32),
+			},
+			"countdown": {
+				Validate: valid.BoolValidator(),
+			},
 			ui.AttrChildren: {
 				Required: false,
-				Validate: valid.ChildrenValidator(0, 1),
+				Validate: valid.ChildrenValidator(0, 2),
 			},
 		},
 	})
@@ -77,9 +84,15 @@ func RegisterAll() error {
 			"height": {
 				Validate: valid.FloatValidator(80.0, math.MaxFloat32),
 			},
+			"timeout": {
+				Validate: valid.FloatValidator(0.1, math.MaxFloat32),
+			},
+			"countdown": {
+				Validate: valid.BoolValidator(),
+			},
 			ui.AttrChildren: {
 				Required: false,
-				Validate: valid.ChildrenValidator(0, 1),
+				Validate: valid.ChildrenValidator(0, 2),
 			},
 		},
 	})
@@ -116,9 +129,15 @@ func RegisterAll() error {
 			"height": {
 				Validate: valid.FloatValidator(80.0, math.MaxFloat32),
 			},
+			"timeout": {
+				Validate: valid.FloatValidator(0.1, math.MaxFloat32),
+			},
+			"countdown": {
+				Validate: valid.BoolValidator(),
+			},
 			ui.AttrChildren: {
 				Required: true,
-				Validate: valid.ChildrenValidator(2, 2),
+				Validate: valid.ChildrenValidator(2, 3),
 			},
 		},
 	})
@@ -136,9 +155,15 @@ func RegisterAll() error {
 				Required: true,
 				Validate: valid.ExactStringValidator("openFile"),
 			},
+			"title": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"multiple": {
+				Validate: valid.BoolValidator(),
+			},
 			"extensions": {
 				Validate: valid.ListValidator(1, math.MaxInt,
-					valid.StringValidator(2, 0, extensionRegex)),
+					valid.StringValidator(2, 0, ui.ExtensionRegex)),
 			},
 			"cancelText": {
 				Validate: valid.StringValidator(1, 0, nil),
@@ -152,12 +177,19 @@ func RegisterAll() error {
 			"height": {
 				Validate: valid.FloatValidator(80.0, math.MaxFloat32),
 			},
+			"timeout": {
+				Validate: valid.FloatValidator(0.1, math.MaxFloat32),
+			},
+			"countdown": {
+				Validate: valid.BoolValidator(),
+			},
 			ui.AttrOutputKey: valid.ValidateOutputKey,
 			ui.AttrChildren: {
 				Required: true,
-				Validate: valid.ChildrenValidator(2, 2),
+				Validate: valid.ChildrenValidator(2, 3),
 			},
 		},
+		Validate: validateOpenFile,
 	})
 	if err != nil {
 		return err
@@ -175,7 +207,7 @@ func RegisterAll() error {
 			},
 			"extensions": {
 				Validate: valid.ListValidator(1, math.MaxInt,
-					valid.StringValidator(2, 0, extensionRegex)),
+					valid.StringValidator(2, 0, ui.ExtensionRegex)),
 			},
 			"cancelText": {
 				Validate: valid.StringValidator(1, 0, nil),
@@ -189,10 +221,16 @@ func RegisterAll() error {
 			"height": {
 				Validate: valid.FloatValidator(80.0, math.MaxFloat32),
 			},
+			"timeout": {
+				Validate: valid.FloatValidator(0.1, math.MaxFloat32),
+			},
+			"countdown": {
+				Validate: valid.BoolValidator(),
+			},
 			ui.AttrOutputKey: valid.ValidateOutputKey,
 			ui.AttrChildren: {
 				Required: true,
-				Validate: valid.ChildrenValidator(2, 2),
+				Validate: valid.ChildrenValidator(2, 3),
 			},
 		},
 	})
@@ -222,10 +260,16 @@ func RegisterAll() error {
 			"height": {
 				Validate: valid.FloatValidator(80.0, math.MaxFloat32),
 			},
+			"timeout": {
+				Validate: valid.FloatValidator(0.1, math.MaxFloat32),
+			},
+			"countdown": {
+				Validate: valid.BoolValidator(),
+			},
 			ui.AttrOutputKey: valid.ValidateOutputKey,
 			ui.AttrChildren: {
 				Required: true,
-				Validate: valid.ChildrenValidator(2, 2),
+				Validate: valid.ChildrenValidator(2, 3),
 			},
 		},
 	})
@@ -255,6 +299,12 @@ func RegisterAll() error {
 			"height": {
 				Validate: valid.FloatValidator(80.0, math.MaxFloat32),
 			},
+			"timeout": {
+				Validate: valid.FloatValidator(0.1, math.MaxFloat32),
+			},
+			"countdown": {
+				Validate: valid.BoolValidator(),
+			},
 			"advanced": {
 				Validate: valid.BoolValidator(),
 			},
@@ -264,7 +314,220 @@ func RegisterAll() error {
 			ui.AttrOutputKey: valid.ValidateOutputKey,
 			ui.AttrChildren: {
 				Required: true,
-				Validate: valid.ChildrenValidator(2, 2),
+				Validate: valid.ChildrenValidator(2, 3),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordDialog, "entry", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordDialog),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("entry"),
+			},
+			"title": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"message": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"placeHolder": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"initialValue": {
+				Validate: valid.StringValidator(0, 0, nil),
+			},
+			"password": {
+				Validate: valid.BoolValidator(),
+			},
+			"required": {
+				Validate: valid.BoolValidator(),
+			},
+			"confirmText": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"dismissText": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"width": {
+				Validate: valid.FloatValidator(50.0, math.MaxFloat32),
+			},
+			"height": {
+				Validate: valid.FloatValidator(80.0, math.MaxFloat32),
+			},
+			"timeout": {
+				Validate: valid.FloatValidator(0.1, math.MaxFloat32),
+			},
+			"countdown": {
+				Validate: valid.BoolValidator(),
+			},
+			ui.AttrOutputKey: valid.ValidateOutputKey,
+			ui.AttrChildren: {
+				Required: true,
+				Validate: valid.ChildrenValidator(2, 3),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordDialog, "form", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordDialog),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("form"),
+			},
+			"title": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"confirmText": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"dismissText": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"width": {
+				Validate: valid.FloatValidator(50.0, math.MaxFloat32),
+			},
+			"height": {
+				Validate: valid.FloatValidator(80.0, math.MaxFloat32),
+			},
+			"timeout": {
+				Validate: valid.FloatValidator(0.1, math.MaxFloat32),
+			},
+			"countdown": {
+				Validate: valid.BoolValidator(),
+			},
+			ui.AttrChildren: {
+				Required: true,
+				Validate: valid.ChildrenValidator(3, math.MaxInt),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordDialog, "progress", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordDialog),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("progress"),
+			},
+			"title": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"message": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"indeterminate": {
+				Validate: valid.BoolValidator(),
+			},
+			"autoClose": {
+				Validate: valid.BoolValidator(),
+			},
+			"finishText": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"cancelText": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"width": {
+				Validate: valid.FloatValidator(50.0, math.MaxFloat32),
+			},
+			"height": {
+				Validate: valid.FloatValidator(80.0, math.MaxFloat32),
+			},
+			"timeout": {
+				Validate: valid.FloatValidator(0.1, math.MaxFloat32),
+			},
+			"countdown": {
+				Validate: valid.BoolValidator(),
+			},
+			ui.AttrChildren: {
+				Required: false,
+				Validate: valid.ChildrenValidator(0, 3),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordDialog, "custom", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordDialog),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("custom"),
+			},
+			"title": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"message": {
+				Required: true,
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"cancelButton": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"width": {
+				Validate: valid.FloatValidator(50.0, math.MaxFloat32),
+			},
+			"height": {
+				Validate: valid.FloatValidator(80.0, math.MaxFloat32),
+			},
+			"timeout": {
+				Validate: valid.FloatValidator(0.1, math.MaxFloat32),
+			},
+			"countdown": {
+				Validate: valid.BoolValidator(),
+			},
+			ui.AttrOutputKey: valid.ValidateOutputKey,
+			ui.AttrChildren: {
+				Required: true,
+				Validate: valid.ChildrenValidator(1, math.MaxInt),
+			},
+		},
+		Validate: ValidateCustomDialog,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordButton, "", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordButton),
+			},
+			"text": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"importance": {
+				Validate: valid.StringValidator(1, 0, uiwidget.ImportanceRegex),
+			},
+			ui.AttrChildren: {
+				Validate: valid.ChildrenValidator(1, math.MaxInt),
 			},
 		},
 	})
@@ -284,24 +547,113 @@ func RegisterAll() error {
 }
 
 func runDialog(dialogDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) {
-	dlg := dialogDescr[ui.AttrType]
+	var state *dialogState
 
-	switch dlg {
+	switch dlg := dialogDescr[ui.AttrType]; dlg {
 	case "info":
-		runInfo(dialogDescr, fullName, win, uiDescr)
+		state = runInfo(dialogDescr, fullName, win, uiDescr)
 	case "error":
-		runError(dialogDescr, fullName, win, uiDescr)
+		state = runError(dialogDescr, fullName, win, uiDescr)
 	case "confirmation":
-		runConfirmation(dialogDescr, fullName, win, uiDescr)
+		state = runConfirmation(dialogDescr, fullName, win, uiDescr)
 	case "openFile":
-		runOpenFile(dialogDescr, fullName, win, uiDescr)
+		state = runOpenFile(dialogDescr, fullName, win, uiDescr)
 	case "saveFile":
-		runSaveFile(dialogDescr, fullName, win, uiDescr)
+		state = runSaveFile(dialogDescr, fullName, win, uiDescr)
 	case "openFolder":
-		runOpenFolder(dialogDescr, fullName, win, uiDescr)
+		state = runOpenFolder(dialogDescr, fullName, win, uiDescr)
 	case "pickColor":
-		runPickColor(dialogDescr, fullName, win, uiDescr)
+		state = runPickColor(dialogDescr, fullName, win, uiDescr)
+	case "entry":
+		state = runEntry(dialogDescr, fullName, win, uiDescr)
+	case "form":
+		state = runForm(dialogDescr, fullName, win, uiDescr)
+	case "progress":
+		state = runProgress(dialogDescr, fullName, win, uiDescr)
+	case "custom":
+		state = runCustom(dialogDescr, fullName, win, uiDescr)
 	default:
 		log.Printf(`ERROR: for %q: unknown dialog type %q`, fullName, dlg)
+		return
+	}
+
+	state.startTimeout(dialogDescr, uiDescr)
+}
+
+// shownDialog is a dialog of Fyne that has been shown.
+// The file dialogs of Fyne don't implement dialog.Dialog.
+type shownDialog interface {
+	Hide()
+	SetOnClosed(closed func())
+}
+
+// dialogState is the state of a shown dialog:
+// its key bindings (e.g. "Escape" for canceling it) and if it has been closed.
+// The key bindings are only active while the dialog is shown, so they don't replace
+// the shortcuts of the window for good.
+type dialogState struct {
+	win      fyne.Window
+	fullName string
+	dlg      shownDialog
+	removes  []func()
+	closed   atomic.Bool
+}
+
+func newDialogState(win fyne.Window, fullName string) *dialogState {
+	return &dialogState{win: win, fullName: fullName}
+}
+
+// shown records the shown dialog and returns the state.
+func (s *dialogState) shown(dlg shownDialog) *dialogState {
+	s.dlg = dlg
+	return s
+}
+
+// addKeys binds the keys to the function in the window.
+func (s *dialogState) addKeys(f func(), keys ...string) {
+	for _, key := range keys {
+		remove, err := ui.AddShortcut(s.win, key, f)
+		if err != nil {
+			log.Printf("ERROR: for %q: %v", s.fullName, err)
+			continue
+		}
+		s.removes = append(s.removes, remove)
+	}
+}
+
+// removeKeys removes the key bindings, so earlier bindings of the same keys work again.
+// It has to be called when the dialog is closed; calling it twice does no harm.
+func (s *dialogState) removeKeys() {
+	for _, remove := range s.removes {
+		remove()
+	}
+}
+
+// close removes the key bindings and returns true if the dialog hasn't been closed before.
+func (s *dialogState) close() bool {
+	s.removeKeys()
+	return !s.closed.Swap(true)
+}
+
+// closing returns a callback that is only called for the first closing of the dialog.
+// So hiding a dialog (e.g. after a timeout or a key) doesn't run another child.
+func (s *dialogState) closing(callback func(bool)) func(bool) {
+	return func(ok bool) {
+		if s.close() {
+			callback(ok)
+		}
 	}
 }
+
+// startTimeout starts the timeout of the dialog if it has the attribute "timeout".
+// The timeout stops when the dialog is closed.
+func (s *dialogState) startTimeout(dialogDescr ui.AttributesDescr, uiDescr ui.CommandsDescr) {
+	if s == nil || s.dlg == nil {
+		return
+	}
+	stop := run.Timeout(dialogDescr, s.fullName, s.win, uiDescr, func() {
+		s.close() // only the "timeout" child runs
+		s.dlg.Hide()
+	})
+	s.dlg.SetOnClosed(stop)
+}
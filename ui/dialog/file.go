@@ -2,20 +2,43 @@ package dialog
 
 import (
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
 	"github.com/flowdev/fdialog/run"
 	"github.com/flowdev/fdialog/ui"
+	"log"
+	"slices"
 	"strings"
 )
 
-func runOpenFile(ofDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) {
+// validateOpenFile checks that the title is only given for multiple files,
+// because the file dialog of Fyne has no title.
+func validateOpenFile(attrs ui.AttributesDescr, parent string) bool {
+	if multiple, _ := attrs["multiple"].(bool); multiple {
+		return true
+	}
+	if _, ok := attrs["title"]; ok {
+		log.Printf("ERROR: for %q: the title is only supported for multiple files", parent)
+		return false
+	}
+	return true
+}
+
+func runOpenFile(ofDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) *dialogState {
 	outputKey, _ := ofDescr[ui.AttrOutputKey].(string)
 	id, _ := ofDescr[ui.AttrID].(string)
 	group, _ := ofDescr[ui.AttrGroup].(string)
 	callback := run.BooleanCallback(ofDescr[ui.AttrChildren].(ui.CommandsDescr),
 		ui.NameChoose, ui.NameCancel, fullName, win, uiDescr)
-	ofDialog := dialog.NewFileOpen(func(frd fyne.URIReadCloser, err error) {
+	if multiple, _ := ofDescr["multiple"].(bool); multiple {
+		return runOpenFiles(ofDescr, fullName, win, callback)
+	}
+	state := newDialogState(win, fullName)
+	callback = state.closing(callback)
+	onFile := func(frd fyne.URIReadCloser, err error) {
 		if err != nil {
 			dialog.ShowError(err, win)
 			callback(false)
@@ -28,7 +51,8 @@ func runOpenFile(ofDescr ui.AttributesDescr, fullName string, win fyne.Window, u
 		fileName := strings.TrimPrefix(frd.URI().String(), "file://")
 		ui.StoreValue(fileName, outputKey, id, fullName, group)
 		callback(true)
-	}, win)
+	}
+	ofDialog := dialog.NewFileOpen(onFile, win)
 
 	extAttr := ofDescr["extensions"]
 	if extAttr != nil {
@@ -49,22 +73,130 @@ func runOpenFile(ofDescr ui.AttributesDescr, fullName string, win fyne.Window, u
 		ofDialog.Resize(fyne.NewSize(width, height))
 	}
 
-	win.Canvas().SetOnTypedKey(func(keyEvent *fyne.KeyEvent) {
-		if keyEvent.Name == fyne.KeyEscape {
-			win.Close()
+	state.addKeys(func() { // like the cancel button
+		ofDialog.Hide()
+		onFile(nil, nil)
+	}, "Escape")
+
+	ofDialog.Show()
+	return state.shown(ofDialog)
+}
+
+// runOpenFiles lets the user open multiple files one after the other
+// (the file dialog of Fyne supports only a single file).
+// The chosen files are shown in a list and stored as a list.
+func runOpenFiles(ofDescr ui.AttributesDescr, fullName string, win fyne.Window, callback func(bool)) *dialogState {
+	outputKey, _ := ofDescr[ui.AttrOutputKey].(string)
+	id, _ := ofDescr[ui.AttrID].(string)
+	group, _ := ofDescr[ui.AttrGroup].(string)
+	title := "Open Files"
+	if t, ok := ofDescr["title"].(string); ok {
+		title = t
+	}
+	chooseText := "Open"
+	if t, ok := ofDescr["chooseText"].(string); ok {
+		chooseText = t
+	}
+	cancelText := "Cancel"
+	if t, ok := ofDescr["cancelText"].(string); ok {
+		cancelText = t
+	}
+
+	var files []string
+	selected := -1
+	list := widget.NewList(
+		func() int { return len(files) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(i widget.ListItemID, obj fyne.CanvasObject) { obj.(*widget.Label).SetText(files[i]) },
+	)
+	list.OnSelected = func(i widget.ListItemID) { selected = i }
+
+	var filesDialog *dialog.CustomDialog
+	choose := widget.NewButton(chooseText, func() {
+		filesDialog.Hide()
+		values := make([]any, len(files))
+		for i, f := range files {
+			values[i] = f
 		}
+		ui.StoreValue(values, outputKey, id, fullName, group)
+		callback(true)
 	})
+	choose.Importance = widget.HighImportance
+	choose.Disable()
+	doCancel := func() {
+		filesDialog.Hide()
+		callback(false)
+	}
+	cancel := widget.NewButton(cancelText, doCancel)
+	remove := widget.NewButtonWithIcon("Remove", theme.ContentRemoveIcon(), func() {
+		if selected < 0 || selected >= len(files) {
+			return
+		}
+		files = slices.Delete(files, selected, selected+1)
+		selected = -1
+		list.UnselectAll()
+		list.Refresh()
+		if len(files) == 0 {
+			choose.Disable()
+		}
+	})
+	add := func() {
+		fileState := newDialogState(win, fullName)
+		onFile := func(frd fyne.URIReadCloser, err error) {
+			fileState.removeKeys()
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			if frd == nil {
+				return
+			}
+			_ = frd.Close()
+			fileName := strings.TrimPrefix(frd.URI().String(), "file://")
+			if !slices.Contains(files, fileName) {
+				files = append(files, fileName)
+				list.Refresh()
+			}
+			choose.Enable()
+		}
+		ofDialog := dialog.NewFileOpen(onFile, win)
+		if extAttr := ofDescr["extensions"]; extAttr != nil {
+			ofDialog.SetFilter(storage.NewExtensionFileFilter(ui.AnysToStrings(extAttr)))
+		}
+		fileState.addKeys(func() { // only the file dialog on top is canceled
+			ofDialog.Hide()
+			onFile(nil, nil)
+		}, "Escape")
+		ofDialog.Show()
+	}
+	addButton := widget.NewButtonWithIcon("Add", theme.ContentAddIcon(), add)
 
-	ofDialog.Show()
+	content := container.NewBorder(nil, container.NewHBox(addButton, remove), nil, nil, list)
+	filesDialog = dialog.NewCustomWithoutButtons(title, content, win)
+	state := newDialogState(win, fullName)
+	filesDialog.SetOnClosed(state.removeKeys)
+	filesDialog.SetButtons([]fyne.CanvasObject{cancel, choose})
+
+	width, height := run.GetSize(ofDescr)
+	if width > 0 && height > 0 {
+		filesDialog.Resize(fyne.NewSize(width, height))
+	}
+
+	state.addKeys(doCancel, "Escape")
+
+	filesDialog.Show()
+	add() // the user wants to open at least one file
+	return state.shown(filesDialog)
 }
 
-func runSaveFile(sfDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) {
+func runSaveFile(sfDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) *dialogState {
 	outputKey, _ := sfDescr[ui.AttrOutputKey].(string)
 	id, _ := sfDescr[ui.AttrID].(string)
 	group, _ := sfDescr[ui.AttrGroup].(string)
-	callback := run.BooleanCallback(sfDescr[ui.AttrChildren].(ui.CommandsDescr),
-		ui.NameChoose, ui.NameCancel, fullName, win, uiDescr)
-	sfDialog := dialog.NewFileSave(func(fwr fyne.URIWriteCloser, err error) {
+	state := newDialogState(win, fullName)
+	callback := state.closing(run.BooleanCallback(sfDescr[ui.AttrChildren].(ui.CommandsDescr),
+		ui.NameChoose, ui.NameCancel, fullName, win, uiDescr))
+	onFile := func(fwr fyne.URIWriteCloser, err error) {
 		if err != nil {
 			dialog.ShowError(err, win)
 			callback(false)
@@ -78,7 +210,8 @@ func runSaveFile(sfDescr ui.AttributesDescr, fullName string, win fyne.Window, u
 		fileName := strings.TrimPrefix(fwr.URI().String(), "file://")
 		ui.StoreValue(fileName, outputKey, id, fullName, group)
 		callback(true)
-	}, win)
+	}
+	sfDialog := dialog.NewFileSave(onFile, win)
 
 	extAttr := sfDescr["extensions"]
 	if extAttr != nil {
@@ -99,24 +232,26 @@ func runSaveFile(sfDescr ui.AttributesDescr, fullName string, win fyne.Window, u
 		sfDialog.Resize(fyne.NewSize(width, height))
 	}
 
-	win.Canvas().SetOnTypedKey(func(keyEvent *fyne.KeyEvent) {
-		if keyEvent.Name == fyne.KeyEscape {
-			win.Close()
-		}
-	})
+	state.addKeys(func() { // like the cancel button
+		sfDialog.Hide()
+		onFile(nil, nil)
+	}, "Escape")
 
 	sfDialog.Show()
+	return state.shown(sfDialog)
 }
 
-func runOpenFolder(ofDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) {
+func runOpenFolder(ofDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) *dialogState {
 	outputKey, _ := ofDescr[ui.AttrOutputKey].(string)
 	id, _ := ofDescr[ui.AttrID].(string)
 	group, _ := ofDescr[ui.AttrGroup].(string) // group is optional with zero value as default
-	callback := run.BooleanCallback(ofDescr[ui.AttrChildren].(ui.CommandsDescr),
-		ui.NameChoose, ui.NameCancel, fullName, win, uiDescr)
+	state := newDialogState(win, fullName)
+	callback := state.closing(run.BooleanCallback(ofDescr[ui.AttrChildren].(ui.CommandsDescr),
+		ui.NameChoose, ui.NameCancel, fullName, win, uiDescr))
 
-	ofDialog := dialog.NewFolderOpen(func(fold fyne.ListableURI, err error) {
+	onFolder := func(fold fyne.ListableURI, err error) {
 		if err != nil {
+			state.removeKeys()
 			dialog.ShowError(err, win)
 			return
 		}
@@ -127,7 +262,8 @@ func runOpenFolder(ofDescr ui.AttributesDescr, fullName string, win fyne.Window,
 		folderName := strings.TrimPrefix(fold.String(), "file://")
 		ui.StoreValue(folderName, outputKey, id, fullName, group)
 		callback(true)
-	}, win)
+	}
+	ofDialog := dialog.NewFolderOpen(onFolder, win)
 
 	value := ofDescr["chooseText"]
 	if value != nil {
@@ -143,11 +279,11 @@ func runOpenFolder(ofDescr ui.AttributesDescr, fullName string, win fyne.Window,
 		ofDialog.Resize(fyne.NewSize(width, height))
 	}
 
-	win.Canvas().SetOnTypedKey(func(keyEvent *fyne.KeyEvent) {
-		if keyEvent.Name == fyne.KeyEscape {
-			win.Close()
-		}
-	})
+	state.addKeys(func() { // like the cancel button
+		ofDialog.Hide()
+		onFolder(nil, nil)
+	}, "Escape")
 
 	ofDialog.Show()
+	return state.shown(ofDialog)
 }
@@ -0,0 +1,171 @@
+package dialog
+
+import (
+	"errors"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/flowdev/fdialog/run"
+	"github.com/flowdev/fdialog/ui"
+	uiwidget "github.com/flowdev/fdialog/ui/widget"
+	"log"
+)
+
+func runEntry(entryDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) *dialogState {
+	state := newDialogState(win, fullName)
+	callback := state.closing(run.BooleanCallback(entryDescr[ui.AttrChildren].(ui.CommandsDescr),
+		ui.NameChoose, ui.NameCancel, fullName, win, uiDescr))
+	title, _ := entryDescr["title"].(string)     // title is optional with zero value as default
+	message, _ := entryDescr["message"].(string) // message is optional with zero value as default
+	outputKey, _ := entryDescr[ui.AttrOutputKey].(string)
+	id, _ := entryDescr[ui.AttrID].(string)
+	group, _ := entryDescr[ui.AttrGroup].(string)
+
+	entry := newEscapeEntry()
+	entry.Password, _ = entryDescr["password"].(bool)
+	if ph, ok := entryDescr["placeHolder"].(string); ok {
+		entry.SetPlaceHolder(ph)
+	}
+	if initial, ok := entryDescr["initialValue"].(string); ok {
+		entry.SetText(initial)
+	}
+	if required, _ := entryDescr["required"].(bool); required {
+		entry.Validator = func(s string) error {
+			if s == "" {
+				return errors.New("value is required")
+			}
+			return nil
+		}
+	}
+	ui.StoreObject(entry, fullName)
+
+	// the dialog is created by us (and not by dialog.NewForm),
+	// so hiding it never runs the callback a second time
+	confirmText, dismissText := buttonTexts(entryDescr)
+	form := widget.NewForm(widget.NewFormItem(message, entry))
+	entryDialog := dialog.NewCustomWithoutButtons(title, form, win)
+	cancel := func() {
+		entryDialog.Hide()
+		callback(false)
+	}
+	dismiss := widget.NewButtonWithIcon(dismissText, theme.CancelIcon(), cancel)
+	confirm := widget.NewButtonWithIcon(confirmText, theme.ConfirmIcon(), func() {
+		entryDialog.Hide()
+		ui.StoreValue(entry.Text, outputKey, id, fullName, group)
+		callback(true)
+	})
+	confirm.Importance = widget.HighImportance
+	setSubmitState := func(err error) {
+		if err != nil {
+			confirm.Disable()
+		} else {
+			confirm.Enable()
+		}
+	}
+	setSubmitState(entry.Validate())
+	form.SetOnValidationChanged(setSubmitState)
+	entry.OnSubmitted = func(string) {
+		if !confirm.Disabled() {
+			confirm.OnTapped()
+		}
+	}
+	entry.onEscape = cancel
+	entryDialog.SetButtons([]fyne.CanvasObject{dismiss, confirm})
+
+	width, height := run.GetSize(entryDescr)
+	if width > 0 && height > 0 {
+		entryDialog.Resize(fyne.NewSize(width, height))
+	}
+
+	state.addKeys(cancel, "Escape")
+
+	entryDialog.Show()
+	win.Canvas().Focus(entry)
+	return state.shown(entryDialog)
+}
+
+// escapeEntry is an entry that cancels its dialog when Escape is typed,
+// because the keys of the window don't work while the entry has the focus.
+type escapeEntry struct {
+	widget.Entry
+	onEscape func()
+}
+
+func newEscapeEntry() *escapeEntry {
+	entry := &escapeEntry{}
+	entry.ExtendBaseWidget(entry)
+	return entry
+}
+
+func (e *escapeEntry) TypedKey(key *fyne.KeyEvent) {
+	if key.Name == fyne.KeyEscape && e.onEscape != nil {
+		e.onEscape()
+		return
+	}
+	e.Entry.TypedKey(key)
+}
+
+func runForm(formDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) *dialogState {
+	children := formDescr[ui.AttrChildren].(ui.CommandsDescr)
+	state := newDialogState(win, fullName)
+	callback := state.closing(run.BooleanCallback(children, ui.NameChoose, ui.NameCancel, fullName, win, uiDescr))
+	title, _ := formDescr["title"].(string)     // title is optional with zero value as default
+	group, _ := formDescr[ui.AttrName].(string) // default value
+	if g, ok := formDescr[ui.AttrGroup].(string); ok {
+		group = g
+	}
+
+	// the form is created by us (and not by dialog.NewForm),
+	// so items can be shown and hidden later
+	form, validate, store := uiwidget.Form(children, fullName, group, win, uiDescr)
+	confirmText, dismissText := buttonTexts(formDescr)
+	formDialog := dialog.NewCustomWithoutButtons(title, form, win)
+	cancel := func() {
+		formDialog.Hide()
+		callback(false)
+	}
+	dismiss := widget.NewButtonWithIcon(dismissText, theme.CancelIcon(), cancel)
+	confirm := widget.NewButtonWithIcon(confirmText, theme.ConfirmIcon(), func() {
+		if err := validate(); err != nil { // the user can correct the form
+			log.Printf("INFO: for %q: form isn't valid: %v", fullName, err)
+			return
+		}
+		formDialog.Hide()
+		store()
+		callback(true)
+	})
+	confirm.Importance = widget.HighImportance
+	setSubmitState := func(err error) {
+		if err != nil {
+			confirm.Disable()
+		} else {
+			confirm.Enable()
+		}
+	}
+	setSubmitState(form.Validate())
+	form.SetOnValidationChanged(setSubmitState)
+	formDialog.SetButtons([]fyne.CanvasObject{dismiss, confirm})
+
+	width, height := run.GetSize(formDescr)
+	if width > 0 && height > 0 {
+		formDialog.Resize(fyne.NewSize(width, height))
+	}
+
+	state.addKeys(cancel, "Escape")
+
+	formDialog.Show()
+	return state.shown(formDialog)
+}
+
+// buttonTexts returns the texts of the confirm and dismiss buttons.
+func buttonTexts(descr ui.AttributesDescr) (confirmText, dismissText string) {
+	confirmText, dismissText = "OK", "Cancel"
+	if t, ok := descr["confirmText"].(string); ok {
+		confirmText = t
+	}
+	if t, ok := descr["dismissText"].(string); ok {
+		dismissText = t
+	}
+	return confirmText, dismissText
+}
@@ -8,14 +8,12 @@ import (
 	"github.com/flowdev/fdialog/ui"
 )
 
-func runInfo(infoDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) {
+func runInfo(infoDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) *dialogState {
 	title, _ := infoDescr["title"].(string)  // title is optional with zero value as default
 	message := infoDescr["message"].(string) // message is required
 	info := dialog.NewInformation(title, message, win)
-	if children, ok := infoDescr[ui.AttrChildren]; ok {
-		callback := run.CloseCallback(children.(ui.CommandsDescr), fullName, win, uiDescr)
-		info.SetOnClosed(callback)
-	}
+	state := newDialogState(win, fullName)
+	info.SetOnClosed(closeCallback(infoDescr, state, fullName, win, uiDescr))
 
 	value := infoDescr["buttonText"]
 	if value != nil {
@@ -27,23 +25,17 @@ func runInfo(infoDescr ui.AttributesDescr, fullName string, win fyne.Window, uiD
 		info.Resize(fyne.NewSize(width, height))
 	}
 
-	win.Canvas().SetOnTypedKey(func(keyEvent *fyne.KeyEvent) {
-		switch keyEvent.Name {
-		case fyne.KeyReturn, fyne.KeyEnter, fyne.KeySpace, fyne.KeyEscape:
-			win.Close()
-		}
-	})
+	state.addKeys(win.Close, "Return", "KPEnter", "Space", "Escape")
 
 	info.Show()
+	return state.shown(info)
 }
 
-func runError(errorDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) {
+func runError(errorDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) *dialogState {
 	message := errorDescr["message"].(string) // message is required
 	errorDialog := dialog.NewError(errors.New(message), win)
-	if children, ok := errorDescr[ui.AttrChildren]; ok {
-		callback := run.CloseCallback(children.(ui.CommandsDescr), fullName, win, uiDescr)
-		errorDialog.SetOnClosed(callback)
-	}
+	state := newDialogState(win, fullName)
+	errorDialog.SetOnClosed(closeCallback(errorDescr, state, fullName, win, uiDescr))
 
 	value := errorDescr["buttonText"]
 	if value != nil {
@@ -55,20 +47,17 @@ func runError(errorDescr ui.AttributesDescr, fullName string, win fyne.Window, u
 		errorDialog.Resize(fyne.NewSize(width, height))
 	}
 
-	win.Canvas().SetOnTypedKey(func(keyEvent *fyne.KeyEvent) {
-		switch keyEvent.Name {
-		case fyne.KeyReturn, fyne.KeyEnter, fyne.KeySpace, fyne.KeyEscape:
-			win.Close()
-		}
-	})
+	state.addKeys(win.Close, "Return", "KPEnter", "Space", "Escape")
 
 	ui.StoreExitCode(0) // error has been noted; so all is OK
 	errorDialog.Show()
+	return state.shown(errorDialog)
 }
 
-func runConfirmation(cnfDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) {
-	callback := run.BooleanCallback(cnfDescr[ui.AttrChildren].(ui.CommandsDescr),
-		ui.NameConfirm, ui.NameDismiss, fullName, win, uiDescr)
+func runConfirmation(cnfDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) *dialogState {
+	state := newDialogState(win, fullName)
+	callback := state.closing(run.BooleanCallback(cnfDescr[ui.AttrChildren].(ui.CommandsDescr),
+		ui.NameConfirm, ui.NameDismiss, fullName, win, uiDescr))
 	title, _ := cnfDescr["title"].(string)  // title is optional with zero value as default
 	message := cnfDescr["message"].(string) // message is required
 	cnf := dialog.NewConfirm(title, message, callback, win)
@@ -86,14 +75,36 @@ func runConfirmation(cnfDescr ui.AttributesDescr, fullName string, win fyne.Wind
 	if width > 0 && height > 0 {
 		cnf.Resize(fyne.NewSize(width, height))
 	}
-	win.Canvas().SetOnTypedKey(func(keyEvent *fyne.KeyEvent) {
-		switch keyEvent.Name {
-		case fyne.KeyReturn, fyne.KeyEnter, fyne.KeySpace:
-			callback(true)
-		case fyne.KeyEscape:
-			callback(false)
-		}
-	})
+	state.addKeys(func() {
+		callback(true)
+		cnf.Hide() // the callback isn't called again
+	}, "Return", "KPEnter", "Space")
+	state.addKeys(func() {
+		callback(false)
+		cnf.Hide()
+	}, "Escape")
 
 	cnf.Show()
+	return state.shown(cnf)
+}
+
+// closeCallback returns the function called when an info or error dialog is closed.
+// It removes the key bindings of the dialog and runs the optional "close" child
+// (but not after a timeout).
+func closeCallback(
+	descr ui.AttributesDescr,
+	state *dialogState,
+	fullName string,
+	win fyne.Window,
+	uiDescr ui.CommandsDescr,
+) func() {
+	callback := func() {}
+	if children, ok := descr[ui.AttrChildren]; ok {
+		callback = run.CloseCallback(children.(ui.CommandsDescr), fullName, win, uiDescr)
+	}
+	return func() {
+		if state.close() {
+			callback()
+		}
+	}
 }
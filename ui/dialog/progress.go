@@ -0,0 +1,236 @@
+package dialog
+
+import (
+	"bufio"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/flowdev/fdialog/run"
+	"github.com/flowdev/fdialog/ui"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// progress is the state of a progress dialog.
+// It is updated by the goroutine reading the input.
+type progress struct {
+	mu        sync.Mutex
+	done      bool     // finished or canceled
+	app       fyne.App // the input is ignored after the app has exited
+	fullName  string
+	autoClose bool
+	label     *widget.Label
+	bar       *widget.ProgressBar         // determinate mode
+	infinite  *widget.ProgressBarInfinite // indeterminate mode
+	cancel    *widget.Button              // nil without cancel action
+	finish    *widget.Button
+	dlg       *dialog.CustomDialog
+	onFinish  func()
+	onCancel  func()
+}
+
+// ReadsInput returns true if the UI description contains a progress dialog
+// that reads standard input.
+func ReadsInput(uiDescr ui.CommandsDescr) bool {
+	for _, descr := range uiDescr.All() {
+		if descr[ui.AttrKeyword] == KeywordDialog && descr[ui.AttrType] == "progress" {
+			return true
+		}
+		if children, ok := descr[ui.AttrChildren].(ui.CommandsDescr); ok && ReadsInput(children) {
+			return true
+		}
+	}
+	return false
+}
+
+// runProgress shows a progress dialog that is driven by lines read from
+// standard input (like `zenity --progress`):
+// a number sets the percentage and a line starting with '#' sets the label.
+// The progress is complete at 100% or at the end of the input.
+func runProgress(progDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) *dialogState {
+	title, _ := progDescr["title"].(string) // title is optional with zero value as default
+	message, _ := progDescr["message"].(string)
+	p := &progress{
+		app:      ui.App(),
+		fullName: fullName,
+		label:    widget.NewLabel(message),
+	}
+	p.autoClose, _ = progDescr["autoClose"].(bool)
+
+	var bar fyne.CanvasObject
+	if indeterminate, _ := progDescr["indeterminate"].(bool); indeterminate {
+		p.infinite = widget.NewProgressBarInfinite()
+		bar = p.infinite
+	} else {
+		p.bar = widget.NewProgressBar()
+		bar = p.bar
+	}
+
+	children, _ := progDescr[ui.AttrChildren].(ui.CommandsDescr)
+	p.onFinish = win.Close // default
+	if children != nil {
+		if keyFinish, ok := children.Get(ui.NameFinish); ok {
+			p.onFinish = func() {
+				run.Keyword(keyFinish, ui.FullNameFor(fullName, ui.NameFinish), win, uiDescr)
+			}
+		}
+	}
+	finishText := "OK"
+	if t, ok := progDescr["finishText"].(string); ok {
+		finishText = t
+	}
+	p.finish = widget.NewButton(finishText, func() {
+		p.dlg.Hide()
+		p.onFinish()
+	})
+	p.finish.Importance = widget.HighImportance
+	p.finish.Disable()
+	buttons := []fyne.CanvasObject{p.finish}
+	if p.autoClose {
+		buttons = nil
+	}
+
+	if children != nil {
+		if keyCancel, ok := children.Get(ui.NameCancel); ok {
+			p.onCancel = func() {
+				run.Keyword(keyCancel, ui.FullNameFor(fullName, ui.NameCancel), win, uiDescr)
+			}
+			cancelText := "Cancel"
+			if t, ok := progDescr["cancelText"].(string); ok {
+				cancelText = t
+			}
+			p.cancel = widget.NewButton(cancelText, p.doCancel)
+			buttons = append([]fyne.CanvasObject{p.cancel}, buttons...)
+		}
+	}
+
+	p.dlg = dialog.NewCustomWithoutButtons(title, container.NewVBox(p.label, bar), win)
+	p.dlg.SetButtons(buttons)
+	width, height := run.GetSize(progDescr)
+	if width > 0 && height > 0 {
+		p.dlg.Resize(fyne.NewSize(width, height))
+	}
+	state := newDialogState(win, fullName)
+	p.dlg.SetOnClosed(func() { // e.g. after a timeout
+		state.removeKeys()
+		p.mu.Lock()
+		p.done = true
+		p.mu.Unlock()
+	})
+	state.addKeys(func() {
+		if p.cancel != nil {
+			p.doCancel()
+		}
+	}, "Escape")
+
+	p.dlg.Show()
+	go p.read(run.Input())
+	return state.shown(p.dlg)
+}
+
+// read reads the progress lines until the end of the input or
+// until the progress is complete.
+// The lines are handled with ui.Do, because they change the dialog.
+func (p *progress) read(rd io.Reader) {
+	scanner := bufio.NewScanner(rd)
+	for scanner.Scan() {
+		done := true
+		ui.Do(func() {
+			if ui.App() == p.app {
+				done = p.handleLine(scanner.Text())
+			}
+		})
+		if done {
+			return
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		log.Printf("ERROR: for %q: unable to read the progress: %v", p.fullName, err)
+	}
+	ui.Do(func() {
+		if ui.App() == p.app {
+			p.complete()
+		}
+	})
+}
+
+// handleLine handles a single line of input and returns true if the
+// progress is done.
+func (p *progress) handleLine(line string) bool {
+	p.mu.Lock()
+	done := p.done
+	p.mu.Unlock()
+	if done {
+		return true
+	}
+
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return false
+	}
+	if text, ok := strings.CutPrefix(line, "#"); ok {
+		p.label.SetText(strings.TrimSpace(text))
+		return false
+	}
+	percent, err := strconv.ParseFloat(line, 64)
+	if err != nil {
+		log.Printf("WARNING: for %q: ignoring progress line %q", p.fullName, line)
+		return false
+	}
+	percent = max(0, min(percent, 100))
+	if p.bar != nil {
+		p.bar.SetValue(percent / 100)
+	}
+	if percent >= 100 {
+		p.complete()
+		return true
+	}
+	return false
+}
+
+// complete finishes the progress automatically or lets the user do it.
+func (p *progress) complete() {
+	p.mu.Lock()
+	if p.done {
+		p.mu.Unlock()
+		return
+	}
+	p.done = true
+	p.mu.Unlock()
+
+	if p.infinite != nil {
+		p.infinite.Stop()
+	}
+	if p.bar != nil {
+		p.bar.SetValue(1)
+	}
+	if p.cancel != nil {
+		p.cancel.Disable()
+	}
+	if p.autoClose {
+		p.dlg.Hide()
+		p.onFinish()
+		return
+	}
+	p.finish.Enable()
+}
+
+func (p *progress) doCancel() {
+	p.mu.Lock()
+	if p.done {
+		p.mu.Unlock()
+		return
+	}
+	p.done = true
+	p.mu.Unlock()
+
+	if p.infinite != nil {
+		p.infinite.Stop()
+	}
+	p.dlg.Hide()
+	p.onCancel()
+}
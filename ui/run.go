@@ -1,16 +1,28 @@
 package ui
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"sync/atomic"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 )
 
+// ExitCodeTimeout is the exit code used when a timeout expires without
+// a timeout action (like zenity does).
+const ExitCodeTimeout = 5
+
 var fapp fyne.App // needed for exiting cleanly in actions
 var exitCode = new(atomic.Int32)
+var exitFunc = os.Exit // can be replaced for running a UI description in tests
+var baseDir string     // directory of the UI description file
+var uiLock sync.Mutex  // serializes changes of the UI from different goroutines
 
 // ---------------------------------------------------------------------------
 // Helpers
@@ -23,6 +35,18 @@ func NewApp(appid string) {
 	fapp = app.NewWithID(appid)
 }
 
+// SetApp sets the app to use instead of creating a new one with NewApp.
+// This allows running a UI description without a display
+// (e.g. with the test app of Fyne).
+func SetApp(a fyne.App) {
+	fapp = a
+}
+
+// App returns the running app or nil if it has exited already.
+func App() fyne.App {
+	return fapp
+}
+
 func RunApp() {
 	fapp.Run()
 }
@@ -34,7 +58,28 @@ func ExitApp(code int) {
 		code = int(exitCode.Load())
 	}
 	log.Printf("INFO: exiting app with code: %d", code)
-	os.Exit(code)
+	exitFunc(code)
+}
+
+// SetExitFunc replaces the function that ends the process in ExitApp.
+// So a UI description can be run in tests without ending the test.
+// A nil function restores the default: os.Exit.
+func SetExitFunc(f func(code int)) {
+	if f == nil {
+		f = os.Exit
+	}
+	exitFunc = f
+}
+
+// Do runs f while no other function given to Do is running.
+// Goroutines (e.g. of actions running in the background) change the UI and
+// the stored values only with Do, so they don't interfere with each other or
+// with the user simulated by a test script.
+// Fyne 2.5 has no way to run a function in its own event loop.
+func Do(f func()) {
+	uiLock.Lock()
+	defer uiLock.Unlock()
+	f()
 }
 
 func NewWindow(title string) fyne.Window {
@@ -46,6 +91,22 @@ func StoreExitCode(code int32) {
 	exitCode.Store(code)
 }
 
+// SetBaseDir sets the directory of the UI description file.
+// Relative paths in the UI description (e.g. of images) are relative to it.
+// An empty directory means the current working directory.
+func SetBaseDir(dir string) {
+	baseDir = dir
+}
+
+// ResolvePath returns the path relative to the directory of the UI description file.
+// Absolute paths are returned unchanged.
+func ResolvePath(path string) string {
+	if baseDir == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(baseDir, path)
+}
+
 func AnysToStrings(a any) []string {
 	al, ok := a.([]any)
 	if !ok {
@@ -57,3 +118,34 @@ func AnysToStrings(a any) []string {
 	}
 	return sl
 }
+
+// StringsToAnys converts a list of strings into a list of values
+// (e.g. for storing it).
+func StringsToAnys(sl []string) []any {
+	al := make([]any, len(sl))
+	for i, s := range sl {
+		al[i] = s
+	}
+	return al
+}
+
+// ValueString converts a stored value into a string (e.g. for command arguments).
+// The elements of lists are separated by commas.
+func ValueString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []any:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = ValueString(e)
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
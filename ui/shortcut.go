@@ -0,0 +1,209 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+// modifierNames maps the names of modifiers in shortcuts (lower case) to Fyne modifiers.
+// "Shortcut" is Ctrl or Cmd (on macOS).
+var modifierNames = map[string]fyne.KeyModifier{
+	"ctrl":     fyne.KeyModifierControl,
+	"control":  fyne.KeyModifierControl,
+	"alt":      fyne.KeyModifierAlt,
+	"shift":    fyne.KeyModifierShift,
+	"super":    fyne.KeyModifierSuper,
+	"cmd":      fyne.KeyModifierSuper,
+	"meta":     fyne.KeyModifierSuper,
+	"shortcut": fyne.KeyModifierShortcutDefault,
+}
+
+// keyNames maps the names of special keys in shortcuts (lower case) to Fyne key names.
+var keyNames = map[string]fyne.KeyName{
+	"escape":    fyne.KeyEscape,
+	"esc":       fyne.KeyEscape,
+	"return":    fyne.KeyReturn,
+	"enter":     fyne.KeyReturn, // the main enter key is meant
+	"kpenter":   fyne.KeyEnter,  // the enter key of the keypad (called Enter in Fyne)
+	"tab":       fyne.KeyTab,
+	"space":     fyne.KeySpace,
+	"backspace": fyne.KeyBackspace,
+	"delete":    fyne.KeyDelete,
+	"insert":    fyne.KeyInsert,
+	"home":      fyne.KeyHome,
+	"end":       fyne.KeyEnd,
+	"pageup":    fyne.KeyPageUp,
+	"pagedown":  fyne.KeyPageDown,
+	"up":        fyne.KeyUp,
+	"down":      fyne.KeyDown,
+	"left":      fyne.KeyLeft,
+	"right":     fyne.KeyRight,
+}
+
+// shortcutMap maps a window to the stacks of functions of its shortcuts per shortcut name.
+// Only the last function of a stack runs; removing it restores the function before.
+var shortcutMap = make(map[fyne.Window]map[string][]*shortcutFunc)
+var shortcutMu sync.Mutex // dialogs can be closed from other goroutines
+
+// shortcutFunc is a function bound to a shortcut.
+// It is a pointer, so the same function can be bound twice and removed once.
+type shortcutFunc struct {
+	f func()
+}
+
+// ParseShortcut parses a key combination like "Ctrl+S", "Shortcut+Shift+N" or "Escape".
+// Keys are letters, digits, function keys (F1 to F12) and special keys
+// like "Escape", "Return" or "Delete"; the case doesn't matter.
+func ParseShortcut(s string) (*desktop.CustomShortcut, error) {
+	parts := strings.Split(s, "+")
+	shortcut := &desktop.CustomShortcut{}
+	for _, part := range parts[:len(parts)-1] {
+		modifier, ok := modifierNames[strings.ToLower(strings.TrimSpace(part))]
+		if !ok {
+			return nil, fmt.Errorf("unknown modifier %q in shortcut %q", part, s)
+		}
+		shortcut.Modifier |= modifier
+	}
+	if shortcut.Modifier == fyne.KeyModifierShift {
+		return nil, fmt.Errorf("shift needs another modifier in shortcut %q", s)
+	}
+
+	key := strings.TrimSpace(parts[len(parts)-1])
+	lowerKey := strings.ToLower(key)
+	switch {
+	case key == "":
+		return nil, fmt.Errorf("key is missing in shortcut %q", s)
+	case len(key) == 1 && (lowerKey >= "a" && lowerKey <= "z" || key >= "0" && key <= "9"):
+		shortcut.KeyName = fyne.KeyName(strings.ToUpper(key))
+	case isFunctionKey(lowerKey):
+		shortcut.KeyName = fyne.KeyName(strings.ToUpper(key))
+	default:
+		name, ok := keyNames[lowerKey]
+		if !ok {
+			return nil, fmt.Errorf("unknown key %q in shortcut %q", key, s)
+		}
+		shortcut.KeyName = name
+	}
+	return shortcut, nil
+}
+
+// isFunctionKey returns true for the names of the function keys "f1" to "f12".
+func isFunctionKey(key string) bool {
+	switch key {
+	case "f1", "f2", "f3", "f4", "f5", "f6", "f7", "f8", "f9", "f10", "f11", "f12":
+		return true
+	}
+	return false
+}
+
+// AddShortcut binds a key combination (see: ParseShortcut) to a function in the window.
+// Keys without modifier (e.g. "Escape") are handled when typed and no widget has the focus.
+// A second function for the same key combination hides the first one until
+// it is removed with the returned function (e.g. when a dialog is closed).
+func AddShortcut(win fyne.Window, s string, f func()) (remove func(), err error) {
+	if win == nil {
+		return nil, errors.New("unable to add a shortcut without window")
+	}
+	shortcut, err := ParseShortcut(s)
+	if err != nil {
+		return nil, err
+	}
+	name := shortcut.ShortcutName()
+	sf := &shortcutFunc{f: f}
+
+	shortcutMu.Lock()
+	shortcuts, ok := shortcutMap[win]
+	if !ok {
+		shortcuts = make(map[string][]*shortcutFunc)
+		shortcutMap[win] = shortcuts
+	}
+	shortcuts[name] = append(shortcuts[name], sf)
+	shortcutMu.Unlock()
+
+	if shortcut.Modifier != 0 {
+		win.Canvas().AddShortcut(shortcut, func(fyne.Shortcut) {
+			runShortcut(win, name)
+		})
+	} else {
+		win.Canvas().SetOnTypedKey(func(event *fyne.KeyEvent) {
+			plain := &desktop.CustomShortcut{KeyName: event.Name}
+			runShortcut(win, plain.ShortcutName())
+		})
+	}
+	return func() {
+		removeShortcut(win, name, sf)
+	}, nil
+}
+
+// removeShortcut removes the function from the stack of the shortcut.
+// Removing it twice does no harm.
+func removeShortcut(win fyne.Window, name string, sf *shortcutFunc) {
+	shortcutMu.Lock()
+	defer shortcutMu.Unlock()
+	stack := shortcutMap[win][name]
+	i := slices.Index(stack, sf)
+	if i < 0 {
+		return
+	}
+	stack = slices.Delete(stack, i, i+1)
+	if len(stack) > 0 {
+		shortcutMap[win][name] = stack
+		return
+	}
+	delete(shortcutMap[win], name)
+	win.Canvas().RemoveShortcut(&namedShortcut{name: name})
+}
+
+// runShortcut runs the last function bound to the shortcut and
+// returns false if there is none.
+func runShortcut(win fyne.Window, name string) bool {
+	shortcutMu.Lock()
+	stack := shortcutMap[win][name]
+	shortcutMu.Unlock()
+	if len(stack) == 0 {
+		return false
+	}
+	stack[len(stack)-1].f() // without lock, so it can add and remove shortcuts
+	return true
+}
+
+// TypeShortcut runs the function bound to the key combination in the window
+// as if it has been typed (e.g. in tests).
+func TypeShortcut(win fyne.Window, s string) error {
+	shortcut, err := ParseShortcut(s)
+	if err != nil {
+		return err
+	}
+	if !runShortcut(win, shortcut.ShortcutName()) {
+		return fmt.Errorf("no shortcut %q found", s)
+	}
+	return nil
+}
+
+// RemoveShortcuts removes all shortcuts from the window
+// (e.g. before its content is rebuilt).
+func RemoveShortcuts(win fyne.Window) {
+	shortcutMu.Lock()
+	defer shortcutMu.Unlock()
+	for name := range shortcutMap[win] {
+		win.Canvas().RemoveShortcut(&namedShortcut{name: name})
+	}
+	win.Canvas().SetOnTypedKey(nil)
+	delete(shortcutMap, win)
+}
+
+// namedShortcut is a shortcut that is only used for its name
+// (e.g. for removing it).
+type namedShortcut struct {
+	name string
+}
+
+func (s *namedShortcut) ShortcutName() string {
+	return s.name
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestAddShortcut(t *testing.T) {
+	specs := []struct {
+		name          string
+		givenShortcut string
+		givenTyped    *desktop.CustomShortcut
+		expectedRun   bool
+	}{
+		{
+			name:          "ctrlEnterOnMainKey",
+			givenShortcut: "Ctrl+Enter",
+			givenTyped:    &desktop.CustomShortcut{KeyName: fyne.KeyReturn, Modifier: fyne.KeyModifierControl},
+			expectedRun:   true,
+		}, {
+			name:          "ctrlEnterNotOnKeypad",
+			givenShortcut: "Ctrl+Enter",
+			givenTyped:    &desktop.CustomShortcut{KeyName: fyne.KeyEnter, Modifier: fyne.KeyModifierControl},
+			expectedRun:   false,
+		}, {
+			name:          "ctrlReturn",
+			givenShortcut: "Ctrl+Return",
+			givenTyped:    &desktop.CustomShortcut{KeyName: fyne.KeyReturn, Modifier: fyne.KeyModifierControl},
+			expectedRun:   true,
+		}, {
+			name:          "ctrlKPEnterOnKeypad",
+			givenShortcut: "Ctrl+KPEnter",
+			givenTyped:    &desktop.CustomShortcut{KeyName: fyne.KeyEnter, Modifier: fyne.KeyModifierControl},
+			expectedRun:   true,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			test.NewTempApp(t)
+			win := test.NewWindow(nil)
+			defer win.Close()
+
+			run := false
+			_, err := AddShortcut(win, spec.givenShortcut, func() { run = true })
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			win.Canvas().(fyne.Shortcutable).TypedShortcut(spec.givenTyped)
+			if run != spec.expectedRun {
+				t.Errorf("expected shortcut to run: %t, got: %t", spec.expectedRun, run)
+			}
+		})
+	}
+}
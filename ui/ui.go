@@ -8,15 +8,19 @@ import (
 	"strings"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
 
 	"github.com/flowdev/fdialog/x/omap"
 )
 
 // Basic keywords:
 const (
-	KeywordWindow = "window"
-	KeywordAction = "action"
-	KeywordLink   = "link"
+	KeywordWindow   = "window"
+	KeywordAction   = "action"
+	KeywordLink     = "link"
+	KeywordMenu     = "menu"
+	KeywordMenuItem = "menuItem"
+	KeywordShortcut = "shortcut"
 )
 
 // Typical names of actions:
@@ -25,7 +29,14 @@ const (
 	NameConfirm = "confirm"
 	NameSubmit  = "submit"
 	NameCancel  = "cancel"
+	NameFinish  = "finish"
 	NameDismiss = "dismiss"
+	NameTimeout = "timeout"
+	NameSuccess = "success"
+	NameFailure = "failure"
+	NameThen    = "then"
+	NameElse    = "else"
+	NameDefault = "default"
 )
 
 // Reserved attribute names:
@@ -72,6 +83,23 @@ var mapFullNameToID = make(map[string]string, 32)
 // fullNames are the display names with '.' inside.
 var valueMap = make(map[string]map[string]any)
 
+// boundValues are the bindings of input widgets whose values aren't
+// submitted by a form (e.g. items in a container) per group and key.
+var boundValues = make(map[string]map[string]binding.DataItem)
+
+// objectMap maps a fullName to the UI object (e.g. widget) created for it.
+var objectMap = make(map[string]fyne.CanvasObject, 32)
+
+// bindingMap maps a fullName to the data binding of the value of an input widget.
+var bindingMap = make(map[string]binding.DataItem, 32)
+
+// bindingKeyMap maps a fullName to the group and output key of the value of an input widget.
+var bindingKeyMap = make(map[string]valueKey, 32)
+
+type valueKey struct {
+	group, outputKey string
+}
+
 // ---------------------------------------------------------------------------
 //  Validation Types & Data
 
@@ -97,6 +125,7 @@ var validKeywords = make(map[ValidKeywordType]ValidAttributesType, 64)
 var NameRegex = regexp.MustCompile(`^[\pL\pN_]+$`)
 var LinkRegex = regexp.MustCompile(`^[\pL\pN_]+(?:[.][\pL\pN_]+)*$`)
 var ColorRegex = regexp.MustCompile(`^#(?:[0-9a-f]{6}|[0-9a-f]{8})$`)
+var ExtensionRegex = regexp.MustCompile(`^\..+$`) // file extension like ".txt"
 
 // ---------------------------------------------------------------------------
 //  Registration & Access
@@ -189,6 +218,13 @@ func RegisterID(id string, fullName string) error {
 	return nil
 }
 
+// DeleteAllIDs removes all registered IDs.
+// This is needed before a UI description is validated again (e.g. for a preview).
+func DeleteAllIDs() {
+	clear(mapIDToFullName)
+	clear(mapFullNameToID)
+}
+
 // FullNameForID returns the full display name for an ID.
 // It returns `false` if nothing was found.
 func FullNameForID(id string) (string, bool) {
@@ -204,6 +240,7 @@ func IDForFullName(fullName string) (string, bool) {
 }
 
 func GetValueByID(id, group string) (any, bool) {
+	storeBoundValues(group)
 	grpMap, ok := valueMap[group]
 	if !ok {
 		return nil, false
@@ -213,6 +250,7 @@ func GetValueByID(id, group string) (any, bool) {
 }
 
 func GetValueByFullName(fullName, group string) (any, bool) {
+	storeBoundValues(group)
 	grpMap, ok := valueMap[group]
 	if !ok {
 		return nil, false
@@ -222,10 +260,40 @@ func GetValueByFullName(fullName, group string) (any, bool) {
 }
 
 func GetValueGroup(group string) (map[string]any, bool) {
+	storeBoundValues(group)
 	grpMap, ok := valueMap[group]
 	return grpMap, ok
 }
 
+// LookupValue returns the value referenced by ref.
+// The reference is a key (output key or ID) in the group or
+// another group and a key separated by a dot (e.g. "form.gender").
+// It returns `false` if nothing was found.
+func LookupValue(ref, group string) (any, bool) {
+	if v, ok := lookupValueInGroup(ref, group); ok {
+		return v, true
+	}
+	if g, key, found := strings.Cut(ref, "."); found {
+		return lookupValueInGroup(key, g)
+	}
+	return nil, false
+}
+
+func lookupValueInGroup(key, group string) (any, bool) {
+	grpMap, ok := GetValueGroup(group)
+	if !ok {
+		return nil, false
+	}
+	if v, ok := grpMap[key]; ok {
+		return v, true
+	}
+	if fullName, ok := mapIDToFullName[key]; ok {
+		v, ok := grpMap[fullName]
+		return v, ok
+	}
+	return nil, false
+}
+
 // StoreValue stores a value in the value map.
 // The group can be used to group multiple values together (e.g. for a form).
 // An empty group is allowed but not encouraged.
@@ -252,6 +320,98 @@ func DeleteValueGroup(group string) {
 
 func DeleteAllValues() {
 	clear(valueMap)
+	clear(boundValues)
+}
+
+// BindValue makes the current value of the binding part of the group,
+// so it is always up to date (e.g. for items in a container).
+// The key is the output key of the value.
+func BindValue(b binding.DataItem, key, group string) {
+	grpBindings, ok := boundValues[group]
+	if !ok {
+		grpBindings = make(map[string]binding.DataItem)
+		boundValues[group] = grpBindings
+	}
+	grpBindings[key] = b
+}
+
+// storeBoundValues stores the current values of all bindings of the group.
+// It is called before values of the group are read.
+func storeBoundValues(group string) {
+	for key, b := range boundValues[group] {
+		StoreValue(BindingValue(b), key, "", "", group)
+	}
+}
+
+// StoreObject stores the UI object (e.g. widget) created for a full name,
+// so it can be found again (e.g. in tests).
+func StoreObject(obj fyne.CanvasObject, fullName string) {
+	objectMap[fullName] = obj
+}
+
+// GetObjectByFullName returns the UI object created for a full name.
+// It returns `false` if nothing was found.
+func GetObjectByFullName(fullName string) (fyne.CanvasObject, bool) {
+	obj, ok := objectMap[fullName]
+	return obj, ok
+}
+
+// GetObjectByID returns the UI object created for the full name
+// registered for an ID.
+// It returns `false` if nothing was found.
+func GetObjectByID(id string) (fyne.CanvasObject, bool) {
+	obj, ok := objectMap[mapIDToFullName[id]]
+	return obj, ok
+}
+
+func DeleteAllObjects() {
+	clear(objectMap)
+	clear(bindingMap)
+	clear(bindingKeyMap)
+}
+
+// StoreBinding stores the data binding of the value of an input widget
+// created for a full name, so the value can be read and changed live.
+// The value of the widget is stored with the output key in the group.
+func StoreBinding(b binding.DataItem, outputKey, fullName, group string) {
+	bindingMap[fullName] = b
+	bindingKeyMap[fullName] = valueKey{group: group, outputKey: outputKey}
+}
+
+// SetBoundValue sets the value of the input widget created for a full name,
+// so the widget shows it immediately.
+// If the value of the widget has been stored already (e.g. by submitting a form),
+// the stored value is updated, too.
+func SetBoundValue(value any, fullName string) error {
+	b, ok := bindingMap[fullName]
+	if !ok {
+		return fmt.Errorf("no input widget found with full name %q", fullName)
+	}
+	if err := SetBindingValue(b, value); err != nil {
+		return err
+	}
+	key := bindingKeyMap[fullName]
+	if grpMap, ok := valueMap[key.group]; ok {
+		if _, ok = grpMap[key.outputKey]; ok {
+			grpMap[key.outputKey] = BindingValue(b)
+		}
+	}
+	return nil
+}
+
+// GetBindingByFullName returns the data binding of an input widget.
+// It returns `false` if nothing was found.
+func GetBindingByFullName(fullName string) (binding.DataItem, bool) {
+	b, ok := bindingMap[fullName]
+	return b, ok
+}
+
+// GetBindingByID returns the data binding of an input widget for the
+// full name registered for an ID.
+// It returns `false` if nothing was found.
+func GetBindingByID(id string) (binding.DataItem, bool) {
+	b, ok := bindingMap[mapIDToFullName[id]]
+	return b, ok
 }
 
 // ---------------------------------------------------------------------------
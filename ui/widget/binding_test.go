@@ -0,0 +1,122 @@
+package widget
+
+import (
+	"reflect"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/flowdev/fdialog/ui"
+)
+
+func TestBindings(t *testing.T) {
+	test.NewTempApp(t)
+
+	specs := []struct {
+		name          string
+		givenCreator  Creator
+		givenAttrs    ui.AttributesDescr
+		givenChange   func(obj fyne.CanvasObject)
+		expectedValue any
+	}{
+		{
+			name:          "entry",
+			givenCreator:  createEntry,
+			givenAttrs:    ui.AttributesDescr{},
+			givenChange:   func(obj fyne.CanvasObject) { test.Type(obj.(*widget.Entry), "Jane") },
+			expectedValue: "Jane",
+		}, {
+			name:          "checkBox",
+			givenCreator:  createCheckBox,
+			givenAttrs:    ui.AttributesDescr{},
+			givenChange:   func(obj fyne.CanvasObject) { obj.(*widget.Check).SetChecked(true) },
+			expectedValue: true,
+		}, {
+			name:          "checkGroup",
+			givenCreator:  createCheckGroup,
+			givenAttrs:    ui.AttributesDescr{"options": []any{"a", "b", "c"}},
+			givenChange:   func(obj fyne.CanvasObject) { obj.(*widget.CheckGroup).SetSelected([]string{"c", "a"}) },
+			expectedValue: []any{"c", "a"},
+		}, {
+			name:          "checkGroupEmpty",
+			givenCreator:  createCheckGroup,
+			givenAttrs:    ui.AttributesDescr{"options": []any{"a"}},
+			givenChange:   func(fyne.CanvasObject) {},
+			expectedValue: []any{},
+		}, {
+			name:          "radioGroup",
+			givenCreator:  createRadioGroup,
+			givenAttrs:    ui.AttributesDescr{"options": []any{"a", "b"}},
+			givenChange:   func(obj fyne.CanvasObject) { obj.(*widget.RadioGroup).SetSelected("b") },
+			expectedValue: "b",
+		}, {
+			name:          "select",
+			givenCreator:  createSelect,
+			givenAttrs:    ui.AttributesDescr{"options": []any{"a", "b"}, "initiallySelected": "a"},
+			givenChange:   func(fyne.CanvasObject) {},
+			expectedValue: "a",
+		}, {
+			name:          "slider",
+			givenCreator:  createSlider,
+			givenAttrs:    ui.AttributesDescr{"initialValue": 20.0},
+			givenChange:   func(obj fyne.CanvasObject) { obj.(*widget.Slider).SetValue(30) },
+			expectedValue: 30.0,
+		}, {
+			name:          "integer",
+			givenCreator:  createNumber,
+			givenAttrs:    ui.AttributesDescr{"integer": true, "locale": "en"},
+			givenChange:   func(obj fyne.CanvasObject) { obj.(*buttonEntry).entry.SetText("41.7") },
+			expectedValue: int64(42),
+		}, {
+			name:          "invalidNumber",
+			givenCreator:  createNumber,
+			givenAttrs:    ui.AttributesDescr{"initialValue": 3.0},
+			givenChange:   func(obj fyne.CanvasObject) { obj.(*buttonEntry).entry.SetText("x") },
+			expectedValue: nil,
+		}, {
+			name:          "date",
+			givenCreator:  createDate,
+			givenAttrs:    ui.AttributesDescr{"format": "02.01.2006"},
+			givenChange:   func(obj fyne.CanvasObject) { obj.(*widget.Entry).SetText("24.12.2024") },
+			expectedValue: "2024-12-24",
+		},
+	}
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			values := make(map[string]binding.DataItem)
+			obj := spec.givenCreator(spec.givenAttrs, values, "key", spec.name)
+			spec.givenChange(obj)
+			b, ok := values["key"]
+			if !ok {
+				t.Fatal("expected a binding but got none")
+			}
+			if got := ui.BindingValue(b); !reflect.DeepEqual(got, spec.expectedValue) {
+				t.Errorf("expected value %#v, got %#v", spec.expectedValue, got)
+			}
+		})
+	}
+}
+
+func TestBindingUpdatesWidget(t *testing.T) {
+	test.NewTempApp(t)
+
+	values := make(map[string]binding.DataItem)
+	cg := createCheckGroup(ui.AttributesDescr{"options": []any{"a", "b"}}, values, "group", "group").(*widget.CheckGroup)
+	if err := values["group"].(binding.Untyped).Set([]any{"b"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(cg.Selected, []string{"b"}) {
+		t.Errorf("expected selection %q, got %q", []string{"b"}, cg.Selected)
+	}
+
+	num := createNumber(ui.AttributesDescr{"decimals": int64(2), "locale": "en"}, values, "num", "num").(*buttonEntry)
+	if err := values["num"].(binding.Untyped).Set(1.5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if num.entry.Text != "1.50" {
+		t.Errorf("expected text %q, got %q", "1.50", num.entry.Text)
+	}
+}
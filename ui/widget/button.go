@@ -0,0 +1,94 @@
+package widget
+
+import (
+	"log"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/flowdev/fdialog/run"
+	"github.com/flowdev/fdialog/ui"
+)
+
+// ValidateToolbar checks that all children are buttons or separators.
+func ValidateToolbar(attrs ui.AttributesDescr, parent string) bool {
+	children, ok := attrs[ui.AttrChildren].(ui.CommandsDescr)
+	if !ok {
+		return true // reported by the children validator
+	}
+	ok = true
+	for name, child := range children.All() {
+		typ := child[ui.AttrType]
+		if child[ui.AttrKeyword] != KeywordFormItem || (typ != TypeButton && typ != "separator") {
+			log.Printf("ERROR: for %q: only items of type %q and %q are allowed as children, got: %q of type %q",
+				ui.FullNameFor(parent, name), TypeButton, "separator", child[ui.AttrKeyword], typ)
+			ok = false
+		}
+	}
+	return ok
+}
+
+func runToolbar(toolbarDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) {
+	win.SetContent(createToolbar(toolbarDescr, fullName, win, uiDescr))
+}
+
+// createToolbar creates a toolbar with buttons and separators.
+// Buttons without importance have low importance like the actions of a Fyne toolbar.
+func createToolbar(toolbarDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) fyne.CanvasObject {
+	children := toolbarDescr[ui.AttrChildren].(ui.CommandsDescr)
+	toolbar := widget.NewToolbar()
+	for name, child := range children.All() {
+		childName := ui.FullNameFor(fullName, name)
+		if child[ui.AttrType] != TypeButton {
+			toolbar.Append(widget.NewToolbarSeparator())
+			continue
+		}
+		button := createButton(child, childName, win, uiDescr)
+		if _, ok := child["importance"]; !ok {
+			button.Importance = widget.LowImportance
+		}
+		if disabled, _ := child["disabled"].(bool); disabled {
+			button.Disable()
+		}
+		ui.StoreObject(button, childName)
+		toolbar.Append(toolbarButton{Button: button})
+	}
+	ui.StoreObject(toolbar, fullName)
+	return toolbar
+}
+
+// toolbarButton is a button with text and icon in a toolbar.
+type toolbarButton struct {
+	*widget.Button
+}
+
+func (b toolbarButton) ToolbarObject() fyne.CanvasObject {
+	return b.Button
+}
+
+// createButton creates a button that runs its children (actions or links) when tapped.
+// The text defaults to the name of the button if it has no icon.
+// The keyboard shortcut (if any) taps the button while it is enabled and visible.
+func createButton(buttonDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) *widget.Button {
+	icon := iconFor(buttonDescr)
+	text, ok := buttonDescr["text"].(string)
+	if !ok && icon == nil {
+		text, _ = buttonDescr[ui.AttrName].(string)
+	}
+	button := widget.NewButtonWithIcon(text, icon, func() {
+		run.Children(buttonDescr[ui.AttrChildren], fullName, win, uiDescr)
+	})
+	button.Importance = ImportanceFor(buttonDescr)
+
+	if shortcut, ok := buttonDescr["shortcut"].(string); ok {
+		_, err := ui.AddShortcut(win, shortcut, func() {
+			if !button.Disabled() && button.Visible() {
+				button.OnTapped()
+			}
+		})
+		if err != nil {
+			log.Printf("ERROR: for %q: %v", fullName, err)
+		}
+	}
+	return button
+}
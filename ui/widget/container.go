@@ -0,0 +1,206 @@
+package widget
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"github.com/flowdev/fdialog/ui"
+	"log"
+	"slices"
+)
+
+// Names of the children of a border container that aren't put into the center:
+const (
+	borderTop    = "top"
+	borderBottom = "bottom"
+	borderLeft   = "left"
+	borderRight  = "right"
+)
+
+func runContainer(contDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) {
+	win.SetContent(createContainer(contDescr, fullName, "", nil, win, uiDescr))
+}
+
+// createContainer creates a container with all of its children.
+// The values of items in the container (and nested containers) are stored
+// in the group of the container.
+// The default group is the group of the enclosing form or container or
+// the name of the outermost container.
+// If in is nil or the container has its own group, the values are collected
+// by the container itself.
+func createContainer(
+	contDescr ui.AttributesDescr,
+	fullName, group string,
+	in *inputs,
+	win fyne.Window,
+	uiDescr ui.CommandsDescr,
+) fyne.CanvasObject {
+	if group == "" {
+		group, _ = contDescr[ui.AttrName].(string) // default value
+	}
+	g, ownGroup := contDescr[ui.AttrGroup].(string)
+	if ownGroup {
+		group = g
+	}
+	ownInputs := in == nil || ownGroup
+	if ownInputs {
+		in = newInputs(group)
+	}
+
+	children := contDescr[ui.AttrChildren].(ui.CommandsDescr)
+	objects := make([]fyne.CanvasObject, 0, children.Len())
+	names := make([]string, 0, children.Len())
+	descrs := make([]ui.AttributesDescr, 0, children.Len())
+	for name, child := range children.All() {
+		childName := ui.FullNameFor(fullName, name)
+		var obj fyne.CanvasObject
+		switch keyword := child[ui.AttrKeyword]; keyword {
+		case KeywordContainer:
+			obj = createContainer(child, childName, group, in, win, uiDescr)
+		case KeywordForm:
+			obj = createForm(child, childName, win, uiDescr)
+		case KeywordWizard:
+			obj = createWizard(child, childName, win, uiDescr)
+		case KeywordToolbar:
+			obj = createToolbar(child, childName, win, uiDescr)
+		case KeywordFormItem:
+			// a single item is shown like in a form (with label and hint) but without buttons
+			item := createFormItem(child, fullName, in, win, uiDescr)
+			if item == nil {
+				continue
+			}
+			form := widget.NewForm(item)
+			in.addForm(form, true)
+			obj = form
+		default:
+			log.Printf("ERROR: for %q: keyword %q isn't allowed in a container", childName, keyword)
+			continue
+		}
+		objects = append(objects, obj)
+		names = append(names, name)
+		descrs = append(descrs, child)
+	}
+
+	if ownInputs {
+		// the values are part of the group all the time
+		for key, b := range in.values {
+			ui.BindValue(b, key, group)
+		}
+		in.update()
+	}
+	cont := layoutContainer(contDescr, objects, names, descrs, fullName)
+	ui.StoreObject(cont, fullName)
+	return cont
+}
+
+func layoutContainer(
+	contDescr ui.AttributesDescr,
+	objects []fyne.CanvasObject,
+	names []string,
+	descrs []ui.AttributesDescr,
+	fullName string,
+) fyne.CanvasObject {
+	typ := contDescr[ui.AttrType].(string)
+	switch typ {
+	case "vbox":
+		return container.NewVBox(objects...)
+	case "hbox":
+		return container.NewHBox(objects...)
+	case "grid":
+		columns, _ := contDescr["columns"].(int64)
+		return container.NewGridWithColumns(int(columns), objects...)
+	case "border":
+		var top, bottom, left, right fyne.CanvasObject
+		center := make([]fyne.CanvasObject, 0, len(objects))
+		for i, obj := range objects {
+			switch names[i] {
+			case borderTop:
+				top = obj
+			case borderBottom:
+				bottom = obj
+			case borderLeft:
+				left = obj
+			case borderRight:
+				right = obj
+			default:
+				center = append(center, obj)
+			}
+		}
+		return container.NewBorder(top, bottom, left, right, center...)
+	case "split":
+		if len(objects) != 2 {
+			log.Printf("ERROR: for %q: a split container needs exactly 2 children, got %d", fullName, len(objects))
+			return container.NewVBox(objects...)
+		}
+		var split *container.Split
+		if vertical, _ := contDescr["vertical"].(bool); vertical {
+			split = container.NewVSplit(objects[0], objects[1])
+		} else {
+			split = container.NewHSplit(objects[0], objects[1])
+		}
+		if offset, ok := contDescr["offset"].(float64); ok { // default offset is 0.5
+			split.SetOffset(offset)
+		}
+		return split
+	case "scroll":
+		if len(objects) != 1 {
+			log.Printf("ERROR: for %q: a scroll container needs exactly 1 child, got %d", fullName, len(objects))
+			return container.NewVBox(objects...)
+		}
+		scroll := container.NewScroll(objects[0])
+		switch contDescr["scroll"] {
+		case "horizontal":
+			scroll.Direction = container.ScrollHorizontalOnly
+		case "vertical":
+			scroll.Direction = container.ScrollVerticalOnly
+		case "none":
+			scroll.Direction = container.ScrollNone
+		}
+		return scroll
+	case "tabs":
+		tabItems := make([]*container.TabItem, len(objects))
+		for i, obj := range objects {
+			tabItems[i] = container.NewTabItemWithIcon(childTitle(descrs[i], names[i]), iconFor(descrs[i]), obj)
+		}
+		tabs := container.NewAppTabs(tabItems...)
+		switch contDescr["tabLocation"] {
+		case "bottom":
+			tabs.SetTabLocation(container.TabLocationBottom)
+		case "leading":
+			tabs.SetTabLocation(container.TabLocationLeading)
+		case "trailing":
+			tabs.SetTabLocation(container.TabLocationTrailing)
+		}
+		return tabs
+	case "accordion":
+		accItems := make([]*widget.AccordionItem, len(objects))
+		for i, obj := range objects {
+			accItems[i] = widget.NewAccordionItem(childTitle(descrs[i], names[i]), obj)
+		}
+		acc := widget.NewAccordion(accItems...)
+		acc.MultiOpen, _ = contDescr["multiOpen"].(bool)
+		for _, open := range ui.AnysToStrings(contDescr["open"]) {
+			if i := slices.Index(names, open); i >= 0 {
+				acc.Open(i)
+			} else {
+				log.Printf("ERROR: for %q: unable to open unknown child %q", fullName, open)
+			}
+		}
+		return acc
+	default:
+		log.Printf("ERROR: for %q: container of type %q isn't supported", fullName, typ)
+		return container.NewVBox(objects...)
+	}
+}
+
+// childTitle returns the title of a tab or accordion section.
+// It is the title or label of the child or its name.
+func childTitle(childDescr ui.AttributesDescr, name string) string {
+	if title, ok := childDescr["title"].(string); ok {
+		return title
+	}
+	if label, ok := childDescr["label"].(string); ok {
+		return label
+	}
+	return name
+}
@@ -3,43 +3,45 @@ package widget
 import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/widget"
 	"github.com/flowdev/fdialog/ui"
 	"log"
 	"net/url"
+	"slices"
 )
 
-func createEntry(attrs ui.AttributesDescr, values map[string]any, outputKey, fullName string) fyne.CanvasObject {
+func createEntry(attrs ui.AttributesDescr, values map[string]binding.DataItem, outputKey, fullName string) fyne.CanvasObject {
 	entry := widget.NewEntry()
 	if ph, _ := attrs["placeHolder"].(string); ph != "" {
 		entry.SetPlaceHolder(ph)
 	}
-	entry.Validator = StringValidator(attrs, fullName)
-	values[outputKey] = &entry.Text
+	values[outputKey] = bindEntry(entry)
+	entry.Validator = StringValidator(attrs, fullName) // after binding, because Bind replaces the validator
 	return entry
 }
 
-func createMultiLineEntry(attrs ui.AttributesDescr, values map[string]any, outputKey, fullName string) fyne.CanvasObject {
+func createMultiLineEntry(attrs ui.AttributesDescr, values map[string]binding.DataItem, outputKey, fullName string) fyne.CanvasObject {
 	entry := widget.NewMultiLineEntry()
 	if ph, _ := attrs["placeHolder"].(string); ph != "" {
 		entry.SetPlaceHolder(ph)
 	}
-	entry.Validator = StringValidator(attrs, fullName)
-	values[outputKey] = &entry.Text
+	values[outputKey] = bindEntry(entry)
+	entry.Validator = StringValidator(attrs, fullName) // after binding, because Bind replaces the validator
 	return entry
 }
 
-func createPasswordEntry(attrs ui.AttributesDescr, values map[string]any, outputKey, fullName string) fyne.CanvasObject {
+func createPasswordEntry(attrs ui.AttributesDescr, values map[string]binding.DataItem, outputKey, fullName string) fyne.CanvasObject {
 	entry := widget.NewPasswordEntry()
 	if ph, _ := attrs["placeHolder"].(string); ph != "" {
 		entry.SetPlaceHolder(ph)
 	}
-	entry.Validator = StringValidator(attrs, fullName)
-	values[outputKey] = &entry.Text
+	values[outputKey] = bindEntry(entry)
+	entry.Validator = StringValidator(attrs, fullName) // after binding, because Bind replaces the validator
 	return entry
 }
 
-func createSelect(attrs ui.AttributesDescr, values map[string]any, outputKey, _ string) fyne.CanvasObject {
+func createSelect(attrs ui.AttributesDescr, values map[string]binding.DataItem, outputKey, _ string) fyne.CanvasObject {
 	sel := widget.NewSelect(ui.AnysToStrings(attrs["options"]), nil)
 	if ph, ok := attrs["placeHolder"].(string); ok {
 		sel.PlaceHolder = ph
@@ -47,40 +49,52 @@ func createSelect(attrs ui.AttributesDescr, values map[string]any, outputKey, _
 	if initial, ok := attrs["initiallySelected"].(string); ok {
 		sel.SetSelected(initial)
 	}
-	values[outputKey] = &sel.Selected
+	values[outputKey] = bindSelected(&sel.Selected, &sel.OnChanged, func(s string) {
+		if s == "" {
+			sel.ClearSelected()
+		} else {
+			sel.SetSelected(s)
+		}
+	})
 	return sel
 }
 
-func createSelectEntry(attrs ui.AttributesDescr, values map[string]any, outputKey, fullName string) fyne.CanvasObject {
+func createSelectEntry(attrs ui.AttributesDescr, values map[string]binding.DataItem, outputKey, fullName string) fyne.CanvasObject {
 	sel := widget.NewSelectEntry(ui.AnysToStrings(attrs["options"]))
 	if ph, _ := attrs["placeHolder"].(string); ph != "" {
 		sel.SetPlaceHolder(ph)
 	}
-	sel.Validator = StringValidator(attrs, fullName)
-	values[outputKey] = &sel.Text
+	values[outputKey] = bindEntry(&sel.Entry)
+	sel.Validator = StringValidator(attrs, fullName) // after binding, because Bind replaces the validator
 	return sel
 }
 
-func createCheckBox(attrs ui.AttributesDescr, values map[string]any, outputKey, _ string) fyne.CanvasObject {
+func createCheckBox(attrs ui.AttributesDescr, values map[string]binding.DataItem, outputKey, _ string) fyne.CanvasObject {
 	subLabel, _ := attrs["subLabel"].(string)
 	box := widget.NewCheck(subLabel, nil)
-	values[outputKey] = &box.Checked
+	value := ui.NewBool()
+	box.Bind(value)
+	values[outputKey] = value
 	return box
 }
 
-func createCheckGroup(attrs ui.AttributesDescr, values map[string]any, outputKey, _ string) fyne.CanvasObject {
+func createCheckGroup(attrs ui.AttributesDescr, values map[string]binding.DataItem, outputKey, _ string) fyne.CanvasObject {
+	value := ui.NewUntyped()
 	cg := widget.NewCheckGroup(ui.AnysToStrings(attrs["options"]), func(selected []string) {
-		result := make([]any, len(selected))
-		for i := 0; i < len(selected); i++ {
-			result[i] = selected[i]
-		}
-		values[outputKey] = &result
+		_ = value.Set(ui.StringsToAnys(selected))
 	})
 	cg.SetSelected(ui.AnysToStrings(attrs["initiallySelected"]))
+	value.AddListener(binding.NewDataListener(func() {
+		v, _ := value.Get()
+		if selected := ui.AnysToStrings(v); !slices.Equal(selected, cg.Selected) {
+			cg.SetSelected(selected)
+		}
+	}))
+	values[outputKey] = value
 	return cg
 }
 
-func createRadioGroup(attrs ui.AttributesDescr, values map[string]any, outputKey, _ string) fyne.CanvasObject {
+func createRadioGroup(attrs ui.AttributesDescr, values map[string]binding.DataItem, outputKey, _ string) fyne.CanvasObject {
 	rg := widget.NewRadioGroup(ui.AnysToStrings(attrs["options"]), nil)
 	horizontal, _ := attrs["horizontal"].(bool)
 	rg.Horizontal = horizontal
@@ -89,28 +103,30 @@ func createRadioGroup(attrs ui.AttributesDescr, values map[string]any, outputKey
 	if initial, ok := attrs["initiallySelected"].(string); ok {
 		rg.SetSelected(initial)
 	}
-	values[outputKey] = &rg.Selected
+	values[outputKey] = bindSelected(&rg.Selected, &rg.OnChanged, rg.SetSelected)
 	return rg
 }
 
-func createSlider(attrs ui.AttributesDescr, values map[string]any, outputKey, _ string) fyne.CanvasObject {
+func createSlider(attrs ui.AttributesDescr, values map[string]binding.DataItem, outputKey, _ string) fyne.CanvasObject {
 	minv, _ := attrs["min"].(float64) // default min is 0
 	maxv, ok := attrs["max"].(float64)
 	if !ok {
 		maxv = 100.0 // default max is 100
 	}
 	slider := widget.NewSlider(minv, maxv)
+	value := ui.NewFloat()
+	slider.Bind(value)
 	if initial, ok := attrs["initialValue"].(float64); ok {
 		slider.SetValue(initial)
 	}
 	if step, ok := attrs["step"].(float64); ok { // default step is 1
 		slider.Step = step
 	}
-	values[outputKey] = &slider.Value
+	values[outputKey] = value
 	return slider
 }
 
-func createRichText(attrs ui.AttributesDescr, _ map[string]any, _, _ string) fyne.CanvasObject {
+func createRichText(attrs ui.AttributesDescr, _ map[string]binding.DataItem, _, _ string) fyne.CanvasObject {
 	text, _ := attrs["text"].(string)
 	rt := widget.NewRichTextFromMarkdown(text)
 	rt.Wrapping = fyne.TextWrapWord
@@ -128,7 +144,7 @@ func createRichText(attrs ui.AttributesDescr, _ map[string]any, _, _ string) fyn
 	return rt
 }
 
-func createHyperlink(attrs ui.AttributesDescr, _ map[string]any, _, fullName string) fyne.CanvasObject {
+func createHyperlink(attrs ui.AttributesDescr, _ map[string]binding.DataItem, _, fullName string) fyne.CanvasObject {
 	text, _ := attrs["text"].(string)
 	surl, _ := attrs["url"].(string)
 	link, err := url.Parse(surl)
@@ -138,6 +154,6 @@ func createHyperlink(attrs ui.AttributesDescr, _ map[string]any, _, fullName str
 	return widget.NewHyperlink(text, link)
 }
 
-func createSeparator(_ ui.AttributesDescr, _ map[string]any, _, _ string) fyne.CanvasObject {
+func createSeparator(_ ui.AttributesDescr, _ map[string]binding.DataItem, _, _ string) fyne.CanvasObject {
 	return widget.NewSeparator()
 }
@@ -0,0 +1,226 @@
+package widget
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/flowdev/fdialog/ui"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// dataRow is a single row of a list, table or tree.
+type dataRow struct {
+	key      string
+	cells    []any
+	children []*dataRow
+}
+
+// text returns the text of a cell or an empty string.
+func (r *dataRow) text(i int) string {
+	if i >= len(r.cells) {
+		return ""
+	}
+	return fmt.Sprint(r.cells[i])
+}
+
+// matches returns true if any cell contains the (lower case) filter text.
+func (r *dataRow) matches(filter string) bool {
+	if filter == "" || strings.Contains(strings.ToLower(r.key), filter) {
+		return true
+	}
+	for i := range r.cells {
+		if strings.Contains(strings.ToLower(r.text(i)), filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesDeep returns true if the row or any of its descendants matches.
+func (r *dataRow) matchesDeep(filter string) bool {
+	if r.matches(filter) {
+		return true
+	}
+	for _, child := range r.children {
+		if child.matchesDeep(filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------
+// Validators
+//
+
+// SimpleValueValidator accepts strings, numbers and booleans.
+func SimpleValueValidator() ui.AttributeValidator {
+	return func(v any, strict bool, parent string) (any, bool) {
+		switch v.(type) {
+		case string, int64, float64, bool:
+			return v, true
+		}
+		log.Printf("ERROR: for %q: expecting a string, number or boolean value, got %s",
+			parent, reflect.ValueOf(v).Kind())
+		return v, false
+	}
+}
+
+// ValidateDataItem checks that a list, table or tree item has got some data.
+func ValidateDataItem(attrs ui.AttributesDescr, parent string) bool {
+	_, okOptions := attrs["options"]
+	_, okFile := attrs["file"]
+	_, okRows := attrs[ui.AttrChildren]
+	if !okOptions && !okFile && !okRows {
+		typ, _ := attrs[ui.AttrType].(string)
+		if typ == "list" {
+			log.Printf(`ERROR: for %q: attribute "options", attribute "file" or row children are required`, parent)
+		} else {
+			log.Printf(`ERROR: for %q: attribute "file" or row children are required`, parent)
+		}
+		return false
+	}
+	return true
+}
+
+// -----------------------------------------------------------------------
+// Loading Data
+//
+
+// rowsFromDescr creates the rows from row commands.
+// The name of a row command is the key of the row.
+func rowsFromDescr(rowsDescr ui.CommandsDescr, fullName string) []*dataRow {
+	rows := make([]*dataRow, 0, rowsDescr.Len())
+	for name, rowDescr := range rowsDescr.All() {
+		if rowDescr[ui.AttrKeyword] != KeywordRow {
+			log.Printf("ERROR: for %q: only rows are allowed, got: %q",
+				ui.FullNameFor(fullName, name), rowDescr[ui.AttrKeyword])
+			continue
+		}
+		row := &dataRow{key: name}
+		if cells, ok := rowDescr["cells"].([]any); ok {
+			row.cells = cells
+		}
+		if children, ok := rowDescr[ui.AttrChildren].(ui.CommandsDescr); ok {
+			row.children = rowsFromDescr(children, ui.FullNameFor(fullName, name))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// rowsFromOptions creates a row for every option.
+// The option is the key and the only cell of the row.
+func rowsFromOptions(options []string) []*dataRow {
+	rows := make([]*dataRow, len(options))
+	for i, option := range options {
+		rows[i] = &dataRow{key: option, cells: []any{option}}
+	}
+	return rows
+}
+
+// loadRows reads the rows from a JSON or CSV file.
+// If header is true, the first row contains the column titles.
+func loadRows(fileName string, header bool) (columns []string, rows []*dataRow, err error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read data file: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".json":
+		var jsonRows []any
+		if err = json.Unmarshal(data, &jsonRows); err != nil {
+			return nil, nil, fmt.Errorf("unable to parse JSON data file %q: %w", fileName, err)
+		}
+		if header && len(jsonRows) > 0 {
+			columns = ui.AnysToStrings(jsonRows[0])
+			jsonRows = jsonRows[1:]
+		}
+		rows, err = rowsFromJSON(jsonRows)
+		return columns, rows, err
+	case ".csv":
+		records, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to parse CSV data file %q: %w", fileName, err)
+		}
+		if header && len(records) > 0 {
+			columns = records[0]
+			records = records[1:]
+		}
+		rows = make([]*dataRow, len(records))
+		for i, record := range records {
+			cells := make([]any, len(record))
+			for j, cell := range record {
+				cells[j] = cell
+			}
+			rows[i] = &dataRow{key: firstCellKey(cells, i), cells: cells}
+		}
+		return columns, rows, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported data file type %q (only .json and .csv are supported)",
+			filepath.Ext(fileName))
+	}
+}
+
+// rowsFromJSON converts JSON values to rows.
+// A row can be an array of cells, a single value or an object with the keys
+// "key", "cells" and "children" (for trees).
+func rowsFromJSON(jsonRows []any) ([]*dataRow, error) {
+	rows := make([]*dataRow, len(jsonRows))
+	for i, jsonRow := range jsonRows {
+		row := &dataRow{}
+		switch jr := jsonRow.(type) {
+		case []any:
+			row.cells = normalizeJSONCells(jr)
+		case map[string]any:
+			if cells, ok := jr["cells"].([]any); ok {
+				row.cells = normalizeJSONCells(cells)
+			}
+			if key, ok := jr["key"]; ok {
+				row.key = fmt.Sprint(normalizeJSONCell(key))
+			}
+			if children, ok := jr["children"].([]any); ok {
+				var err error
+				if row.children, err = rowsFromJSON(children); err != nil {
+					return nil, err
+				}
+			}
+		case string, float64, bool:
+			row.cells = []any{normalizeJSONCell(jr)}
+		default:
+			return nil, fmt.Errorf("unsupported row of type %T at index %d", jsonRow, i)
+		}
+		if row.key == "" {
+			row.key = firstCellKey(row.cells, i)
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+func normalizeJSONCells(cells []any) []any {
+	for i, cell := range cells {
+		cells[i] = normalizeJSONCell(cell)
+	}
+	return cells
+}
+
+// normalizeJSONCell converts numbers to int64 if possible (like the parsers do).
+func normalizeJSONCell(cell any) any {
+	if f, ok := cell.(float64); ok && f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+		return int64(f)
+	}
+	return cell
+}
+
+func firstCellKey(cells []any, i int) string {
+	if len(cells) == 0 {
+		return fmt.Sprint(i)
+	}
+	return fmt.Sprint(cells[0])
+}
@@ -0,0 +1,94 @@
+package widget
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadRows(t *testing.T) {
+	specs := []struct {
+		name            string
+		givenFile       string
+		givenContent    string
+		givenHeader     bool
+		expectedColumns []string
+		expectedKeys    []string
+		expectedCells   [][]any
+		expectedError   bool
+	}{
+		{
+			name:            "csvWithHeader",
+			givenFile:       "data.csv",
+			givenContent:    "Name,Size\ngit,9.8\ngo,227\n",
+			givenHeader:     true,
+			expectedColumns: []string{"Name", "Size"},
+			expectedKeys:    []string{"git", "go"},
+			expectedCells:   [][]any{{"git", "9.8"}, {"go", "227"}},
+		}, {
+			name:          "jsonArrays",
+			givenFile:     "data.json",
+			givenContent:  `[["git", 9.8], ["go", 227]]`,
+			expectedKeys:  []string{"git", "go"},
+			expectedCells: [][]any{{"git", 9.8}, {"go", int64(227)}},
+		}, {
+			name:            "jsonHeaderAndValues",
+			givenFile:       "data.json",
+			givenContent:    `[["Name"], "git", true]`,
+			givenHeader:     true,
+			expectedColumns: []string{"Name"},
+			expectedKeys:    []string{"git", "true"},
+			expectedCells:   [][]any{{"git"}, {true}},
+		}, {
+			name:          "jsonObjects",
+			givenFile:     "data.json",
+			givenContent:  `[{"key": "sys", "cells": ["System"], "children": [["Kernel"]]}]`,
+			expectedKeys:  []string{"sys"},
+			expectedCells: [][]any{{"System"}},
+		}, {
+			name:          "invalidJSON",
+			givenFile:     "data.json",
+			givenContent:  `[["git"]`,
+			expectedError: true,
+		}, {
+			name:          "unsupportedType",
+			givenFile:     "data.txt",
+			givenContent:  "git",
+			expectedError: true,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			fileName := filepath.Join(t.TempDir(), spec.givenFile)
+			if err := os.WriteFile(fileName, []byte(spec.givenContent), 0o600); err != nil {
+				t.Fatalf("unable to write data file: %v", err)
+			}
+			columns, rows, err := loadRows(fileName, spec.givenHeader)
+			if spec.expectedError {
+				if err == nil {
+					t.Fatalf("expected an error but got %d rows", len(rows))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(columns, spec.expectedColumns) {
+				t.Errorf("expected columns %q, got %q", spec.expectedColumns, columns)
+			}
+			if len(rows) != len(spec.expectedKeys) {
+				t.Fatalf("expected %d rows, got %d", len(spec.expectedKeys), len(rows))
+			}
+			for i, row := range rows {
+				if row.key != spec.expectedKeys[i] {
+					t.Errorf("expected key %q for row %d, got %q", spec.expectedKeys[i], i, row.key)
+				}
+				if !reflect.DeepEqual(row.cells, spec.expectedCells[i]) {
+					t.Errorf("expected cells %#v for row %d, got %#v", spec.expectedCells[i], i, row.cells)
+				}
+			}
+		})
+	}
+}
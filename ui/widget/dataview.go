@@ -0,0 +1,478 @@
+package widget
+
+import (
+	"errors"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/flowdev/fdialog/ui"
+	"image/color"
+	"log"
+	"slices"
+	"strings"
+)
+
+const defaultDataMinHeight = 200
+
+// -----------------------------------------------------------------------
+// Creators
+//
+
+func createList(attrs ui.AttributesDescr, values map[string]binding.DataItem, outputKey, fullName string) fyne.CanvasObject {
+	return createDataView("list", attrs, values, outputKey, fullName)
+}
+
+func createTable(attrs ui.AttributesDescr, values map[string]binding.DataItem, outputKey, fullName string) fyne.CanvasObject {
+	return createDataView("table", attrs, values, outputKey, fullName)
+}
+
+func createTree(attrs ui.AttributesDescr, values map[string]binding.DataItem, outputKey, fullName string) fyne.CanvasObject {
+	return createDataView("tree", attrs, values, outputKey, fullName)
+}
+
+// createDataView creates a list, table or tree with rows from row children,
+// options (list only) or a JSON or CSV file.
+// The value is the key (or the row with attribute output="rows") of the
+// selected row or a list of them (attribute multiSelect=true).
+func createDataView(
+	typ string,
+	attrs ui.AttributesDescr,
+	values map[string]binding.DataItem,
+	outputKey, fullName string,
+) fyne.CanvasObject {
+	dv := &dataView{
+		typ:       typ,
+		fullName:  fullName,
+		byKey:     make(map[string]*dataRow),
+		minHeight: defaultDataMinHeight,
+	}
+	dv.columns = ui.AnysToStrings(attrs["columns"])
+	if fileName, ok := attrs["file"].(string); ok {
+		header, _ := attrs["header"].(bool)
+		columns, rows, err := loadRows(ui.ResolvePath(fileName), header)
+		if err != nil {
+			log.Printf("ERROR: for %q: %v", fullName, err)
+		}
+		if len(dv.columns) == 0 {
+			dv.columns = columns
+		}
+		dv.rows = rows
+	} else if options, ok := attrs["options"]; ok {
+		dv.rows = rowsFromOptions(ui.AnysToStrings(options))
+	} else if children, ok := attrs[ui.AttrChildren].(ui.CommandsDescr); ok {
+		dv.rows = rowsFromDescr(children, fullName)
+	}
+	dv.rows = dv.indexRows(dv.rows)
+	dv.multi, _ = attrs["multiSelect"].(bool)
+	dv.outputRows = attrs["output"] == "rows"
+	dv.required, _ = attrs["required"].(bool)
+	if h, ok := attrs["minHeight"].(float64); ok {
+		dv.minHeight = float32(h)
+	}
+
+	value := ui.NewUntyped()
+	dv.onChanged = func() {
+		_ = value.Set(dv.value())
+	}
+	values[outputKey] = value
+
+	switch typ {
+	case "table":
+		dv.view = dv.createTable()
+	case "tree":
+		dv.view = dv.createTree()
+	default:
+		dv.view = dv.createList()
+	}
+	if search, _ := attrs["search"].(bool); search {
+		dv.search = widget.NewEntry()
+		dv.search.SetPlaceHolder("Search")
+		dv.search.OnChanged = dv.setFilter
+	}
+	dv.filterRows()
+	for _, key := range ui.AnysToStrings(attrs["initiallySelected"]) {
+		if err := dv.Select(key); err != nil {
+			log.Printf("ERROR: for %q: unable to select initially: %v", fullName, err)
+		}
+	}
+	dv.onChanged()
+	dv.ExtendBaseWidget(dv)
+	return dv
+}
+
+// -----------------------------------------------------------------------
+// Data View Widget
+//
+
+// dataView shows rows in a list, table or tree with an optional search entry.
+// Selection is tracked by the keys of the rows, so it survives filtering.
+type dataView struct {
+	widget.BaseWidget
+	typ        string
+	fullName   string
+	columns    []string
+	rows       []*dataRow // top level rows
+	byKey      map[string]*dataRow
+	visible    []*dataRow // filtered top level rows
+	filter     string     // lower case
+	multi      bool
+	outputRows bool
+	required   bool
+	minHeight  float32
+	selected   []string // keys in selection order
+
+	search *widget.Entry
+	view   fyne.CanvasObject
+
+	onChanged           func()
+	onValidationChanged func(error)
+}
+
+func (dv *dataView) CreateRenderer() fyne.WidgetRenderer {
+	space := canvas.NewRectangle(color.Transparent)
+	space.SetMinSize(fyne.NewSize(0, dv.minHeight))
+	var top fyne.CanvasObject // a nil *widget.Entry isn't nil
+	if dv.search != nil {
+		top = dv.search
+	}
+	return widget.NewSimpleRenderer(container.NewBorder(top, nil, nil, nil,
+		container.NewStack(space, dv.view)))
+}
+
+// Select selects the row with the key.
+// Rows of a multi select view are added to the selection.
+func (dv *dataView) Select(key string) error {
+	if _, ok := dv.byKey[key]; !ok {
+		return fmt.Errorf("no row with key %q", key)
+	}
+	if dv.multi {
+		if !slices.Contains(dv.selected, key) {
+			dv.selected = append(dv.selected, key)
+		}
+	} else {
+		dv.selected = []string{key}
+	}
+	dv.selectionChanged()
+	return nil
+}
+
+func (dv *dataView) Validate() error {
+	if dv.required && len(dv.selected) == 0 {
+		return errors.New("a selection is required")
+	}
+	return nil
+}
+
+func (dv *dataView) SetOnValidationChanged(callback func(error)) {
+	dv.onValidationChanged = callback
+}
+
+func (dv *dataView) addChangeListener(listener func()) {
+	onChanged := dv.onChanged
+	dv.onChanged = func() {
+		onChanged()
+		listener()
+	}
+}
+
+func (dv *dataView) toggle(key string) {
+	if i := slices.Index(dv.selected, key); i >= 0 {
+		dv.selected = slices.Delete(dv.selected, i, i+1)
+	} else {
+		dv.selected = append(dv.selected, key)
+	}
+	dv.selectionChanged()
+}
+
+func (dv *dataView) selectionChanged() {
+	dv.onChanged()
+	if dv.onValidationChanged != nil {
+		dv.onValidationChanged(dv.Validate())
+	}
+	dv.showSelection()
+}
+
+func (dv *dataView) isSelected(key string) bool {
+	return slices.Contains(dv.selected, key)
+}
+
+// value returns the keys or rows of the selection.
+func (dv *dataView) value() any {
+	vals := make([]any, len(dv.selected))
+	for i, key := range dv.selected {
+		vals[i] = dv.rowValue(dv.byKey[key])
+	}
+	if dv.multi {
+		return vals
+	}
+	if len(vals) == 0 {
+		return nil
+	}
+	return vals[0]
+}
+
+// rowValue returns the key of the row or the row itself.
+// With column titles the row is an object, else it is a list of the cells.
+func (dv *dataView) rowValue(row *dataRow) any {
+	if !dv.outputRows {
+		return row.key
+	}
+	if len(dv.columns) == 0 {
+		return slices.Clone(row.cells)
+	}
+	obj := make(map[string]any, len(dv.columns))
+	for i, column := range dv.columns {
+		if i < len(row.cells) {
+			obj[column] = row.cells[i]
+		} else {
+			obj[column] = nil
+		}
+	}
+	return obj
+}
+
+// indexRows indexes the rows by key recursively and removes rows with
+// duplicate keys.
+func (dv *dataView) indexRows(rows []*dataRow) []*dataRow {
+	result := make([]*dataRow, 0, len(rows))
+	for _, row := range rows {
+		if _, ok := dv.byKey[row.key]; ok {
+			log.Printf("ERROR: for %q: duplicate row key %q", dv.fullName, row.key)
+			continue
+		}
+		dv.byKey[row.key] = row
+		row.children = dv.indexRows(row.children)
+		result = append(result, row)
+	}
+	return result
+}
+
+func (dv *dataView) setFilter(text string) {
+	dv.filter = strings.ToLower(strings.TrimSpace(text))
+	dv.filterRows()
+}
+
+func (dv *dataView) filterRows() {
+	dv.visible = dv.visible[:0]
+	for _, row := range dv.rows {
+		if row.matchesDeep(dv.filter) {
+			dv.visible = append(dv.visible, row)
+		}
+	}
+	if tree, ok := dv.view.(*widget.Tree); ok && dv.filter != "" {
+		tree.OpenAllBranches()
+	}
+	dv.view.Refresh()
+	dv.showSelection()
+}
+
+// showSelection shows the selection in the view.
+// Multi select views show a check icon in front of the rows instead.
+func (dv *dataView) showSelection() {
+	if dv.multi {
+		dv.view.Refresh()
+		return
+	}
+	var key string
+	if len(dv.selected) > 0 {
+		key = dv.selected[0]
+	}
+	switch v := dv.view.(type) {
+	case *widget.List:
+		if i := dv.visibleIndex(key); i >= 0 {
+			v.Select(i)
+		} else {
+			v.UnselectAll()
+		}
+	case *widget.Table:
+		if i := dv.visibleIndex(key); i >= 0 {
+			v.Select(widget.TableCellID{Row: i, Col: 0})
+		} else {
+			v.UnselectAll()
+		}
+	case *widget.Tree:
+		if key != "" {
+			v.Select(key)
+		} else {
+			v.UnselectAll()
+		}
+	}
+}
+
+func (dv *dataView) visibleIndex(key string) int {
+	return slices.IndexFunc(dv.visible, func(row *dataRow) bool { return row.key == key })
+}
+
+// onSelected handles the selection of a row by the user.
+func (dv *dataView) onSelected(key string) {
+	if dv.multi {
+		dv.toggle(key)
+		return
+	}
+	if !dv.isSelected(key) {
+		_ = dv.Select(key)
+	}
+}
+
+// rowText is the text of a row in a list or tree.
+func (dv *dataView) rowText(row *dataRow) string {
+	if len(row.cells) == 0 {
+		return row.key
+	}
+	texts := make([]string, len(row.cells))
+	for i := range row.cells {
+		texts[i] = row.text(i)
+	}
+	return strings.Join(texts, " | ")
+}
+
+func (dv *dataView) checkIcon(key string) fyne.Resource {
+	if dv.isSelected(key) {
+		return theme.CheckButtonCheckedIcon()
+	}
+	return theme.CheckButtonIcon()
+}
+
+// rowTemplate is a label with a check icon in front of it for multi select views.
+func (dv *dataView) rowTemplate() fyne.CanvasObject {
+	icon := widget.NewIcon(nil)
+	if !dv.multi {
+		icon.Hide()
+	}
+	return container.NewBorder(nil, nil, icon, nil, widget.NewLabel(""))
+}
+
+func (dv *dataView) updateRowTemplate(row *dataRow, text string, obj fyne.CanvasObject) {
+	cont := obj.(*fyne.Container)
+	cont.Objects[0].(*widget.Label).SetText(text)
+	if dv.multi {
+		cont.Objects[1].(*widget.Icon).SetResource(dv.checkIcon(row.key))
+	}
+}
+
+func (dv *dataView) createList() fyne.CanvasObject {
+	list := widget.NewList(
+		func() int { return len(dv.visible) },
+		dv.rowTemplate,
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			row := dv.visible[id]
+			dv.updateRowTemplate(row, dv.rowText(row), obj)
+		},
+	)
+	list.OnSelected = func(id widget.ListItemID) {
+		dv.onSelected(dv.visible[id].key)
+		if dv.multi {
+			list.UnselectAll()
+		}
+	}
+	return list
+}
+
+func (dv *dataView) createTable() fyne.CanvasObject {
+	columns := len(dv.columns)
+	for _, row := range dv.rows {
+		columns = max(columns, len(row.cells))
+	}
+	first := 0 // first data column
+	if dv.multi {
+		first = 1 // for the check icons
+	}
+	table := widget.NewTable(
+		func() (int, int) { return len(dv.visible), columns + first },
+		func() fyne.CanvasObject {
+			return container.NewStack(widget.NewIcon(nil), widget.NewLabel(""))
+		},
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			icon := obj.(*fyne.Container).Objects[0].(*widget.Icon)
+			label := obj.(*fyne.Container).Objects[1].(*widget.Label)
+			row := dv.visible[id.Row]
+			if id.Col < first {
+				icon.SetResource(dv.checkIcon(row.key))
+				icon.Show()
+				label.Hide()
+				return
+			}
+			icon.Hide()
+			label.SetText(row.text(id.Col - first))
+			label.Show()
+		},
+	)
+	if len(dv.columns) > 0 {
+		table.ShowHeaderRow = true
+		table.CreateHeader = func() fyne.CanvasObject {
+			return widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+		}
+		table.UpdateHeader = func(id widget.TableCellID, obj fyne.CanvasObject) {
+			text := ""
+			if c := id.Col - first; c >= 0 && c < len(dv.columns) {
+				text = dv.columns[c]
+			}
+			obj.(*widget.Label).SetText(text)
+		}
+	}
+	// size the columns so the longest text fits
+	padding := 4 * theme.Padding()
+	if dv.multi {
+		table.SetColumnWidth(0, theme.IconInlineSize()+padding)
+	}
+	for c := 0; c < columns; c++ {
+		width := float32(0)
+		if c < len(dv.columns) {
+			width = fyne.MeasureText(dv.columns[c], theme.TextSize(), fyne.TextStyle{Bold: true}).Width
+		}
+		for _, row := range dv.rows {
+			width = max(width, fyne.MeasureText(row.text(c), theme.TextSize(), fyne.TextStyle{}).Width)
+		}
+		table.SetColumnWidth(c+first, width+padding)
+	}
+	table.OnSelected = func(id widget.TableCellID) {
+		dv.onSelected(dv.visible[id.Row].key)
+		if dv.multi {
+			table.UnselectAll()
+		}
+	}
+	return table
+}
+
+func (dv *dataView) createTree() fyne.CanvasObject {
+	tree := widget.NewTree(
+		func(uid widget.TreeNodeID) []widget.TreeNodeID {
+			rows := dv.visible
+			if uid != "" {
+				rows = dv.byKey[uid].children
+			}
+			ids := make([]widget.TreeNodeID, 0, len(rows))
+			for _, row := range rows {
+				if row.matchesDeep(dv.filter) {
+					ids = append(ids, row.key)
+				}
+			}
+			return ids
+		},
+		func(uid widget.TreeNodeID) bool {
+			if uid == "" {
+				return true
+			}
+			row, ok := dv.byKey[uid]
+			return ok && len(row.children) > 0
+		},
+		func(_ bool) fyne.CanvasObject {
+			return dv.rowTemplate()
+		},
+		func(uid widget.TreeNodeID, _ bool, obj fyne.CanvasObject) {
+			row := dv.byKey[uid]
+			dv.updateRowTemplate(row, dv.rowText(row), obj)
+		},
+	)
+	tree.OnSelected = func(uid widget.TreeNodeID) {
+		dv.onSelected(uid)
+		if dv.multi {
+			tree.UnselectAll()
+		}
+	}
+	return tree
+}
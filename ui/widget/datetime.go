@@ -0,0 +1,321 @@
+package widget
+
+import (
+	"errors"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/flowdev/fdialog/ui"
+	"github.com/flowdev/fdialog/valid"
+	"log"
+	"strconv"
+	"time"
+)
+
+// dateTimeKind describes the differences between the items of type
+// date, time and dateTime.
+type dateTimeKind struct {
+	isoLayouts    []string // accepted layouts of the attributes min, max and initialValue
+	isoOutput     string   // layout of the values written (ISO-8601)
+	defaultFormat string   // layout used for display and input
+	calendar      bool     // has a calendar popup
+}
+
+var dateTimeKinds = map[string]dateTimeKind{
+	"date": {
+		isoLayouts:    []string{time.DateOnly},
+		isoOutput:     time.DateOnly,
+		defaultFormat: time.DateOnly,
+		calendar:      true,
+	},
+	"time": {
+		isoLayouts:    []string{time.TimeOnly, "15:04"},
+		isoOutput:     time.TimeOnly,
+		defaultFormat: "15:04",
+	},
+	"dateTime": {
+		isoLayouts:    []string{"2006-01-02T15:04:05", "2006-01-02T15:04"},
+		isoOutput:     "2006-01-02T15:04:05",
+		defaultFormat: "2006-01-02 15:04",
+		calendar:      true,
+	},
+}
+
+// -----------------------------------------------------------------------
+// Validators
+//
+
+// ISODateTimeValidator checks that the value is a date, time or date and time
+// (depending on the type) in ISO-8601 format (e.g. "2024-12-31", "23:59" or "2024-12-31T23:59").
+func ISODateTimeValidator(typ string) ui.AttributeValidator {
+	validateString := valid.StringValidator(1, 0, nil)
+	return func(v any, strict bool, parent string) (any, bool) {
+		s, ok := validateString(v, strict, parent)
+		if !ok {
+			return s, false
+		}
+		if _, err := parseISO(s.(string), typ); err != nil {
+			log.Printf("ERROR: for %q: %v", parent, err)
+			return s, false
+		}
+		return s, true
+	}
+}
+
+// ValidateDateTimeItem checks that the attributes min, max and initialValue
+// of a date, time or dateTime item fit together and that the format
+// can be used for input.
+func ValidateDateTimeItem(attrs ui.AttributesDescr, parent string) bool {
+	typ, _ := attrs[ui.AttrType].(string)
+	ok := true
+	minv, okMin := isoAttribute(attrs, "min", typ)
+	maxv, okMax := isoAttribute(attrs, "max", typ)
+	if okMin && okMax && minv.After(maxv) {
+		log.Printf("ERROR: for %q: min %q is after max %q", parent, attrs["min"], attrs["max"])
+		ok = false
+	}
+	if initial, okInit := isoAttribute(attrs, "initialValue", typ); okInit {
+		if (okMin && initial.Before(minv)) || (okMax && initial.After(maxv)) {
+			log.Printf("ERROR: for %q: initial value %q is outside of the allowed range",
+				parent, attrs["initialValue"])
+			ok = false
+		}
+	}
+	if format, okFormat := attrs["format"].(string); okFormat {
+		// a date/time formatted with the format has to be parsable again
+		ref := time.Date(2006, time.January, 2, 15, 4, 0, 0, time.Local)
+		if t, err := time.ParseInLocation(format, ref.Format(format), time.Local); err != nil ||
+			t.Format(dateTimeKinds[typ].isoOutput) != ref.Format(dateTimeKinds[typ].isoOutput) {
+			log.Printf("ERROR: for %q: format %q can't be used for the input of a %s", parent, format, typ)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// -----------------------------------------------------------------------
+// Creators
+//
+
+func createDate(attrs ui.AttributesDescr, values map[string]binding.DataItem, outputKey, fullName string) fyne.CanvasObject {
+	return createDateTimeEntry("date", attrs, values, outputKey, fullName)
+}
+
+func createTime(attrs ui.AttributesDescr, values map[string]binding.DataItem, outputKey, fullName string) fyne.CanvasObject {
+	return createDateTimeEntry("time", attrs, values, outputKey, fullName)
+}
+
+func createDateTime(attrs ui.AttributesDescr, values map[string]binding.DataItem, outputKey, fullName string) fyne.CanvasObject {
+	return createDateTimeEntry("dateTime", attrs, values, outputKey, fullName)
+}
+
+// createDateTimeEntry creates an entry for a date and/or time.
+// The value is stored in ISO-8601 format independent of the format used for display.
+func createDateTimeEntry(
+	typ string,
+	attrs ui.AttributesDescr,
+	values map[string]binding.DataItem,
+	outputKey, fullName string,
+) fyne.CanvasObject {
+	kind := dateTimeKinds[typ]
+	format := kind.defaultFormat
+	if f, ok := attrs["format"].(string); ok {
+		format = f
+	}
+	minv, okMin := isoAttribute(attrs, "min", typ)
+	maxv, okMax := isoAttribute(attrs, "max", typ)
+	required, _ := attrs["required"].(bool)
+	failText, _ := attrs["failText"].(string)
+
+	entry := widget.NewEntry()
+	if ph, _ := attrs["placeHolder"].(string); ph != "" {
+		entry.SetPlaceHolder(ph)
+	}
+	isoValue := func(s string) string {
+		if t, err := time.ParseInLocation(format, s, time.Local); err == nil {
+			return t.Format(kind.isoOutput)
+		}
+		return ""
+	}
+	value := ui.NewString()
+	entry.OnChanged = func(s string) {
+		_ = value.Set(isoValue(s))
+	}
+	entry.Validator = func(s string) error {
+		err := validateDateTime(s, format, required, minv, okMin, maxv, okMax)
+		if err != nil && failText != "" {
+			return errors.New(failText)
+		}
+		return err
+	}
+	if initial, ok := isoAttribute(attrs, "initialValue", typ); ok {
+		entry.SetText(initial.Format(format))
+	}
+	value.AddListener(binding.NewDataListener(func() {
+		s, err := value.Get()
+		if err != nil || s == isoValue(entry.Text) {
+			return
+		}
+		if s == "" {
+			entry.SetText("")
+			return
+		}
+		t, err := parseISO(s, typ)
+		if err != nil {
+			log.Printf("ERROR: for %q: %v", fullName, err)
+			return
+		}
+		entry.SetText(t.Format(format))
+	}))
+	values[outputKey] = value
+
+	if kind.calendar {
+		entry.ActionItem = widget.NewButtonWithIcon("", theme.MenuDropDownIcon(), func() {
+			current, err := time.ParseInLocation(format, entry.Text, time.Local)
+			if err != nil {
+				current = time.Now()
+				if okMin && current.Before(minv) {
+					current = minv
+				}
+			}
+			showCalendar(entry, current, minv, okMin, maxv, okMax, func(day time.Time) {
+				// keep the time of day
+				t := time.Date(day.Year(), day.Month(), day.Day(),
+					current.Hour(), current.Minute(), 0, 0, time.Local)
+				entry.SetText(t.Format(format))
+			}, fullName)
+		})
+	}
+	return entry
+}
+
+func validateDateTime(s, format string, required bool, minv time.Time, okMin bool, maxv time.Time, okMax bool) error {
+	if s == "" {
+		if required {
+			return errors.New("value is required")
+		}
+		return nil
+	}
+	t, err := time.ParseInLocation(format, s, time.Local)
+	if err != nil {
+		return fmt.Errorf("%q doesn't match the format %q", s, format)
+	}
+	if okMin && t.Before(minv) {
+		return fmt.Errorf("%q is before the minimum %q", s, minv.Format(format))
+	}
+	if okMax && t.After(maxv) {
+		return fmt.Errorf("%q is after the maximum %q", s, maxv.Format(format))
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------
+// Calendar
+//
+
+// showCalendar shows a calendar for the month of current in a popup below
+// the object (e.g. an entry).
+// Days outside the range from min to max are disabled.
+func showCalendar(
+	obj fyne.CanvasObject,
+	current, minv time.Time, okMin bool, maxv time.Time, okMax bool,
+	onSelected func(time.Time),
+	fullName string,
+) {
+	c := fyne.CurrentApp().Driver().CanvasForObject(obj)
+	if c == nil {
+		log.Printf("ERROR: for %q: unable to find the canvas for the calendar", fullName)
+		return
+	}
+	var popUp *widget.PopUp
+	content := container.NewStack()
+	var showMonth func(month time.Time)
+	showMonth = func(month time.Time) {
+		content.Objects = []fyne.CanvasObject{
+			calendarMonth(month, minv, okMin, maxv, okMax, showMonth, func(day time.Time) {
+				popUp.Hide()
+				onSelected(day)
+			}),
+		}
+		content.Refresh()
+	}
+	showMonth(time.Date(current.Year(), current.Month(), 1, 0, 0, 0, 0, time.Local))
+	popUp = widget.NewPopUp(content, c)
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(obj)
+	popUp.ShowAtPosition(pos.Add(fyne.NewPos(0, obj.Size().Height)))
+}
+
+func calendarMonth(
+	month, minv time.Time, okMin bool, maxv time.Time, okMax bool,
+	showMonth func(time.Time),
+	onSelected func(time.Time),
+) fyne.CanvasObject {
+	prev := widget.NewButtonWithIcon("", theme.NavigateBackIcon(), func() {
+		showMonth(month.AddDate(0, -1, 0))
+	})
+	next := widget.NewButtonWithIcon("", theme.NavigateNextIcon(), func() {
+		showMonth(month.AddDate(0, 1, 0))
+	})
+	if okMin && !month.After(minv) {
+		prev.Disable()
+	}
+	if okMax && month.AddDate(0, 1, 0).After(maxv) {
+		next.Disable()
+	}
+	title := widget.NewLabel(month.Format("January 2006"))
+	title.TextStyle = fyne.TextStyle{Bold: true}
+	header := container.NewHBox(prev, layout.NewSpacer(), title, layout.NewSpacer(), next)
+
+	days := container.NewGridWithColumns(7)
+	for _, wd := range []string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"} {
+		days.Add(widget.NewLabelWithStyle(wd, fyne.TextAlignCenter, fyne.TextStyle{Italic: true}))
+	}
+	offset := (int(month.Weekday()) + 6) % 7 // weeks start on Monday (ISO-8601)
+	for i := 0; i < offset; i++ {
+		days.Add(layout.NewSpacer())
+	}
+	for day := month; day.Month() == month.Month(); day = day.AddDate(0, 0, 1) {
+		d := day
+		button := widget.NewButton(strconv.Itoa(d.Day()), func() {
+			onSelected(d)
+		})
+		button.Importance = widget.LowImportance
+		if (okMin && !d.AddDate(0, 0, 1).After(minv)) || (okMax && d.After(maxv)) {
+			button.Disable()
+		}
+		days.Add(button)
+	}
+	return container.NewVBox(header, days)
+}
+
+// -----------------------------------------------------------------------
+// Helpers
+//
+
+func parseISO(s, typ string) (time.Time, error) {
+	kind, ok := dateTimeKinds[typ]
+	if !ok {
+		return time.Time{}, fmt.Errorf("unknown date/time type %q", typ)
+	}
+	for _, l := range kind.isoLayouts {
+		if t, err := time.ParseInLocation(l, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q isn't a valid ISO-8601 %s", s, typ)
+}
+
+// isoAttribute returns the parsed value of an attribute in ISO-8601 format.
+// It returns false if the attribute doesn't exist or isn't valid.
+func isoAttribute(attrs ui.AttributesDescr, name, typ string) (time.Time, bool) {
+	s, ok := attrs[name].(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := parseISO(s, typ)
+	return t, err == nil
+}
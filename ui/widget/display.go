@@ -0,0 +1,136 @@
+package widget
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/widget"
+	"github.com/flowdev/fdialog/ui"
+	"log"
+)
+
+const defaultImageSize = 64
+
+// -----------------------------------------------------------------------
+// Validators
+//
+
+// ValidateImageItem checks that exactly one source of the image is given.
+func ValidateImageItem(attrs ui.AttributesDescr, parent string) bool {
+	n := 0
+	for _, src := range []string{"file", "base64", "svg"} {
+		if _, ok := attrs[src]; ok {
+			n++
+		}
+	}
+	if n != 1 {
+		log.Printf(`ERROR: for %q: exactly one of the attributes "file", "base64" or "svg" is required, got %d`,
+			parent, n)
+		return false
+	}
+	if data, ok := attrs["base64"].(string); ok {
+		if _, err := base64.StdEncoding.DecodeString(data); err != nil {
+			log.Printf("ERROR: for %q: unable to decode base64 image: %v", parent, err)
+			return false
+		}
+	}
+	return true
+}
+
+// -----------------------------------------------------------------------
+// Creators
+//
+
+// createImage creates an image from a file, base64 encoded data
+// (PNG, JPEG or SVG) or SVG markup.
+func createImage(attrs ui.AttributesDescr, _ map[string]binding.DataItem, _, fullName string) fyne.CanvasObject {
+	var img *canvas.Image
+	switch {
+	case attrs["file"] != nil:
+		img = canvas.NewImageFromFile(ui.ResolvePath(attrs["file"].(string)))
+	case attrs["base64"] != nil:
+		data, err := base64.StdEncoding.DecodeString(attrs["base64"].(string))
+		if err != nil {
+			log.Printf("ERROR: for %q: unable to decode base64 image: %v", fullName, err)
+		}
+		img = canvas.NewImageFromResource(imageResource(fullName, data))
+	default:
+		img = canvas.NewImageFromResource(imageResource(fullName, []byte(attrs["svg"].(string))))
+	}
+
+	switch attrs["fill"] {
+	case "stretch":
+		img.FillMode = canvas.ImageFillStretch
+	case "original":
+		img.FillMode = canvas.ImageFillOriginal
+	default:
+		img.FillMode = canvas.ImageFillContain
+	}
+	width, height := float32(defaultImageSize), float32(defaultImageSize)
+	if w, ok := attrs["minWidth"].(float64); ok {
+		width = float32(w)
+	}
+	if h, ok := attrs["minHeight"].(float64); ok {
+		height = float32(h)
+	}
+	if img.FillMode != canvas.ImageFillOriginal {
+		img.SetMinSize(fyne.NewSize(width, height))
+	}
+	return img
+}
+
+// imageResource creates a resource for image data.
+// The name tells Fyne if the data is SVG.
+func imageResource(fullName string, data []byte) fyne.Resource {
+	name := fullName
+	if bytes.Contains(data[:min(len(data), 1024)], []byte("<svg")) {
+		name += ".svg"
+	}
+	return fyne.NewStaticResource(name, data)
+}
+
+// createIcon creates an icon of the current theme.
+func createIcon(attrs ui.AttributesDescr, _ map[string]binding.DataItem, _, _ string) fyne.CanvasObject {
+	res := iconFor(attrs)
+	size, ok := attrs["size"].(float64)
+	if !ok {
+		return widget.NewIcon(res)
+	}
+	img := canvas.NewImageFromResource(res)
+	img.FillMode = canvas.ImageFillContain
+	img.SetMinSize(fyne.NewSize(float32(size), float32(size)))
+	return img
+}
+
+// createLabel creates a label with plain text.
+func createLabel(attrs ui.AttributesDescr, _ map[string]binding.DataItem, _, _ string) fyne.CanvasObject {
+	text, _ := attrs["text"].(string)
+	label := widget.NewLabel(text)
+	switch attrs["alignment"] {
+	case "center":
+		label.Alignment = fyne.TextAlignCenter
+	case "trailing":
+		label.Alignment = fyne.TextAlignTrailing
+	}
+	for _, style := range ui.AnysToStrings(attrs["style"]) {
+		switch style {
+		case "bold":
+			label.TextStyle.Bold = true
+		case "italic":
+			label.TextStyle.Italic = true
+		case "monospace":
+			label.TextStyle.Monospace = true
+		}
+	}
+	switch attrs["wrapping"] {
+	case "word":
+		label.Wrapping = fyne.TextWrapWord
+	case "break":
+		label.Wrapping = fyne.TextWrapBreak
+	case "truncate":
+		label.Truncation = fyne.TextTruncateEllipsis
+	}
+	return label
+}
@@ -0,0 +1,149 @@
+package widget
+
+import (
+	"errors"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/flowdev/fdialog/ui"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// -----------------------------------------------------------------------
+// Creators
+//
+
+func createFile(attrs ui.AttributesDescr, values map[string]binding.DataItem, outputKey, fullName string) fyne.CanvasObject {
+	return createPathEntry(false, attrs, values, outputKey, fullName)
+}
+
+func createFolder(attrs ui.AttributesDescr, values map[string]binding.DataItem, outputKey, fullName string) fyne.CanvasObject {
+	return createPathEntry(true, attrs, values, outputKey, fullName)
+}
+
+// createPathEntry creates an entry for the path of a file or folder with a
+// button that opens a file or folder dialog.
+func createPathEntry(
+	folder bool,
+	attrs ui.AttributesDescr,
+	values map[string]binding.DataItem,
+	outputKey, fullName string,
+) fyne.CanvasObject {
+	required, _ := attrs["required"].(bool)
+	mustExist, _ := attrs["mustExist"].(bool)
+	failText, _ := attrs["failText"].(string)
+	startDir, _ := attrs["startDir"].(string)
+	extensions := ui.AnysToStrings(attrs["extensions"])
+
+	entry := widget.NewEntry()
+	if ph, _ := attrs["placeHolder"].(string); ph != "" {
+		entry.SetPlaceHolder(ph)
+	}
+	if initial, ok := attrs["initialValue"].(string); ok {
+		entry.SetText(initial)
+	}
+	values[outputKey] = bindEntry(entry)
+	entry.Validator = func(s string) error { // after binding, because Bind replaces the validator
+		err := validatePath(s, folder, required, mustExist, extensions)
+		if err != nil && failText != "" {
+			return errors.New(failText)
+		}
+		return err
+	}
+
+	browse := widget.NewButtonWithIcon("", theme.FolderOpenIcon(), nil)
+	be := newButtonEntry(entry, browse)
+	browse.OnTapped = func() {
+		win := windowForObject(be)
+		if win == nil {
+			log.Printf("ERROR: for %q: unable to find the window for the dialog", fullName)
+			return
+		}
+		dir := startDir
+		if entry.Text != "" { // start where the current path is
+			dir = entry.Text
+			if !folder {
+				dir = filepath.Dir(dir)
+			}
+		}
+		showPathDialog(folder, dir, extensions, entry.SetText, win)
+	}
+	return be
+}
+
+func showPathDialog(folder bool, dir string, extensions []string, onChosen func(string), win fyne.Window) {
+	var d *dialog.FileDialog
+	if folder {
+		d = dialog.NewFolderOpen(func(uri fyne.ListableURI, err error) {
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			if uri != nil {
+				onChosen(uri.Path())
+			}
+		}, win)
+	} else {
+		d = dialog.NewFileOpen(func(rd fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, win)
+				return
+			}
+			if rd != nil {
+				_ = rd.Close()
+				onChosen(rd.URI().Path())
+			}
+		}, win)
+		if len(extensions) > 0 {
+			d.SetFilter(storage.NewExtensionFileFilter(extensions))
+		}
+	}
+	if dir != "" {
+		if lister, err := storage.ListerForURI(storage.NewFileURI(dir)); err == nil {
+			d.SetLocation(lister)
+		}
+	}
+	d.Show()
+}
+
+func validatePath(s string, folder, required, mustExist bool, extensions []string) error {
+	if s == "" {
+		if required {
+			return errors.New("path is required")
+		}
+		return nil
+	}
+	if !folder && len(extensions) > 0 && !hasExtension(s, extensions) {
+		return fmt.Errorf("%q doesn't have one of the extensions %q", s, extensions)
+	}
+	if !mustExist {
+		return nil
+	}
+	info, err := os.Stat(s)
+	if err != nil {
+		return fmt.Errorf("%q doesn't exist", s)
+	}
+	if folder && !info.IsDir() {
+		return fmt.Errorf("%q isn't a folder", s)
+	}
+	if !folder && info.IsDir() {
+		return fmt.Errorf("%q is a folder", s)
+	}
+	return nil
+}
+
+func hasExtension(path string, extensions []string) bool {
+	ext := filepath.Ext(path)
+	for _, e := range extensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
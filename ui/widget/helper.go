@@ -4,7 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
 	"github.com/flowdev/fdialog/ui"
+	"github.com/flowdev/fdialog/valid"
 	"log"
 	"regexp"
 )
@@ -55,6 +60,160 @@ func StringValidator(child ui.AttributesDescr, fullName string) fyne.StringValid
 	}
 }
 
+// IconValidator checks that the value is the name of an icon of the default theme
+// (e.g. "home", "settings", "mailCompose").
+func IconValidator() ui.AttributeValidator {
+	validateString := valid.StringValidator(1, 0, nil)
+	return func(v any, strict bool, parent string) (any, bool) {
+		s, ok := validateString(v, strict, parent)
+		if !ok {
+			return s, false
+		}
+		if theme.DefaultTheme().Icon(fyne.ThemeIconName(s.(string))) == nil {
+			log.Printf("ERROR: for %q: unknown icon name %q", parent, s)
+			return s, false
+		}
+		return s, true
+	}
+}
+
 // -----------------------------------------------------------------------
 // Helpers
 //
+
+// iconFor returns the icon of the current theme with the name found
+// in the attribute "icon" (or nil if there is no such attribute).
+func iconFor(attrs ui.AttributesDescr) fyne.Resource {
+	name, ok := attrs["icon"].(string)
+	if !ok {
+		return nil
+	}
+	return theme.Icon(fyne.ThemeIconName(name))
+}
+
+// ImportanceFor returns the importance of a button found in the attribute
+// "importance" (or medium importance if there is no such attribute).
+func ImportanceFor(attrs ui.AttributesDescr) widget.Importance {
+	switch attrs["importance"] {
+	case "primary":
+		return widget.HighImportance
+	case "low":
+		return widget.LowImportance
+	case "danger":
+		return widget.DangerImportance
+	case "warning":
+		return widget.WarningImportance
+	case "success":
+		return widget.SuccessImportance
+	default:
+		return widget.MediumImportance
+	}
+}
+
+// windowForObject returns the window that shows the object or nil.
+func windowForObject(obj fyne.CanvasObject) fyne.Window {
+	c := fyne.CurrentApp().Driver().CanvasForObject(obj)
+	if c == nil {
+		return nil
+	}
+	for _, win := range fyne.CurrentApp().Driver().AllWindows() {
+		if win.Canvas() == c {
+			return win
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------
+// Bindings
+//
+
+// bindEntry binds the text of an entry to a new string binding.
+// The binding starts with the current text.
+// Bind replaces the validator of the entry, so it has to be set afterward.
+func bindEntry(entry *widget.Entry) binding.String {
+	value := ui.NewString()
+	_ = value.Set(entry.Text)
+	entry.Bind(value)
+	return value
+}
+
+// bindSelected binds the selected option of a widget without a Bind method
+// (e.g. a select) to a new string binding.
+// The binding starts with the current selection and is set by the
+// OnChanged callback of the widget.
+// Changes of the binding (e.g. by an action) are shown with update.
+func bindSelected(selected *string, onChanged *func(string), update func(string)) binding.String {
+	value := ui.NewString()
+	_ = value.Set(*selected)
+	callback := *onChanged
+	*onChanged = func(s string) {
+		_ = value.Set(s)
+		if callback != nil {
+			callback(s)
+		}
+	}
+	value.AddListener(binding.NewDataListener(func() {
+		if s, err := value.Get(); err == nil && s != *selected {
+			update(s)
+		}
+	}))
+	return value
+}
+
+// -----------------------------------------------------------------------
+// Button Entry Widget
+//
+
+// buttonEntry is an entry with buttons at its end (e.g. for incrementing a number).
+// Typing, validation and disabling are delegated to the entry.
+type buttonEntry struct {
+	widget.BaseWidget
+	entry   *widget.Entry
+	buttons []*widget.Button
+}
+
+func newButtonEntry(entry *widget.Entry, buttons ...*widget.Button) *buttonEntry {
+	be := &buttonEntry{
+		entry:   entry,
+		buttons: buttons,
+	}
+	be.ExtendBaseWidget(be)
+	return be
+}
+
+func (be *buttonEntry) CreateRenderer() fyne.WidgetRenderer {
+	buttons := container.NewHBox()
+	for _, button := range be.buttons {
+		buttons.Add(button)
+	}
+	return widget.NewSimpleRenderer(container.NewBorder(nil, nil, nil, buttons, be.entry))
+}
+
+func (be *buttonEntry) FocusGained()              { be.entry.FocusGained() }
+func (be *buttonEntry) FocusLost()                { be.entry.FocusLost() }
+func (be *buttonEntry) TypedRune(r rune)          { be.entry.TypedRune(r) }
+func (be *buttonEntry) TypedKey(e *fyne.KeyEvent) { be.entry.TypedKey(e) }
+
+func (be *buttonEntry) addChangeListener(listener func()) {
+	be.entry.OnChanged = chain(be.entry.OnChanged, listener)
+}
+
+func (be *buttonEntry) Validate() error { return be.entry.Validate() }
+func (be *buttonEntry) SetOnValidationChanged(callback func(error)) {
+	be.entry.SetOnValidationChanged(callback)
+}
+
+func (be *buttonEntry) Disable() {
+	be.entry.Disable()
+	for _, button := range be.buttons {
+		button.Disable()
+	}
+}
+func (be *buttonEntry) Enable() {
+	be.entry.Enable()
+	for _, button := range be.buttons {
+		button.Enable()
+	}
+}
+func (be *buttonEntry) Disabled() bool { return be.entry.Disabled() }
@@ -0,0 +1,236 @@
+package widget
+
+import (
+	"errors"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/lang"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/flowdev/fdialog/ui"
+	"log"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var LocaleRegex = regexp.MustCompile(`^[a-zA-Z]{2,3}(?:[-_][a-zA-Z0-9]+)*$`)
+
+// commaLanguages are the languages that use a comma as decimal separator
+// (and a dot for grouping digits).
+var commaLanguages = map[string]bool{
+	"bg": true, "ca": true, "cs": true, "da": true, "de": true, "el": true, "es": true,
+	"et": true, "fi": true, "fr": true, "hr": true, "hu": true, "id": true, "it": true,
+	"lt": true, "lv": true, "nb": true, "nl": true, "nn": true, "no": true, "pl": true,
+	"pt": true, "ro": true, "ru": true, "sk": true, "sl": true, "sr": true, "sv": true,
+	"tr": true, "uk": true, "vi": true,
+}
+
+// numberFormat contains everything needed for parsing and formatting numbers.
+type numberFormat struct {
+	decimalSep rune
+	groupSep   rune
+	decimals   int // -1 means as many as needed
+}
+
+func newNumberFormat(locale string, decimals int) numberFormat {
+	language, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	if commaLanguages[strings.ToLower(language)] {
+		return numberFormat{decimalSep: ',', groupSep: '.', decimals: decimals}
+	}
+	return numberFormat{decimalSep: '.', groupSep: ',', decimals: decimals}
+}
+
+// parse parses a number in the format of the locale.
+// Separators for groups of digits are ignored.
+func (nf numberFormat) parse(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	s = strings.Map(func(r rune) rune {
+		switch r {
+		case nf.groupSep, ' ', ' ', '\'':
+			return -1
+		case nf.decimalSep:
+			return '.'
+		}
+		return r
+	}, s)
+	f, err := strconv.ParseFloat(s, 64)
+	if err == nil && (math.IsNaN(f) || math.IsInf(f, 0)) {
+		return 0, errors.New("not a finite number")
+	}
+	return f, err
+}
+
+func (nf numberFormat) format(f float64) string {
+	s := strconv.FormatFloat(f, 'f', nf.decimals, 64)
+	if nf.decimalSep != '.' {
+		s = strings.Replace(s, ".", string(nf.decimalSep), 1)
+	}
+	return s
+}
+
+// -----------------------------------------------------------------------
+// Validators
+//
+
+// ValidateNumberItem checks that the attributes min, max and initialValue
+// of a number item fit together.
+func ValidateNumberItem(attrs ui.AttributesDescr, parent string) bool {
+	ok := true
+	minv, okMin := attrs["min"].(float64)
+	maxv, okMax := attrs["max"].(float64)
+	if okMin && okMax && minv > maxv {
+		log.Printf("ERROR: for %q: min %g is bigger than max %g", parent, minv, maxv)
+		ok = false
+	}
+	if initial, okInit := attrs["initialValue"].(float64); okInit {
+		if (okMin && initial < minv) || (okMax && initial > maxv) {
+			log.Printf("ERROR: for %q: initial value %g is outside of the allowed range", parent, initial)
+			ok = false
+		}
+		if integer, _ := attrs["integer"].(bool); integer && initial != math.Trunc(initial) {
+			log.Printf("ERROR: for %q: initial value %g isn't an integer", parent, initial)
+			ok = false
+		}
+	}
+	if integer, _ := attrs["integer"].(bool); integer {
+		if decimals, _ := attrs["decimals"].(int64); decimals > 0 {
+			log.Printf("ERROR: for %q: an integer can't have %d decimals", parent, decimals)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// -----------------------------------------------------------------------
+// Creator
+//
+
+// createNumber creates an entry for a number with buttons to decrement and
+// increment the number.
+// The value is stored as int64 (attribute integer=true) or float64, or nil if the entry is empty.
+func createNumber(attrs ui.AttributesDescr, values map[string]binding.DataItem, outputKey, _ string) fyne.CanvasObject {
+	locale, ok := attrs["locale"].(string)
+	if !ok {
+		locale = lang.SystemLocale().LanguageString()
+	}
+	integer, _ := attrs["integer"].(bool)
+	decimals := -1
+	if d, ok := attrs["decimals"].(int64); ok {
+		decimals = int(d)
+	}
+	if integer {
+		decimals = 0
+	}
+	nf := newNumberFormat(locale, decimals)
+	minv, okMin := attrs["min"].(float64)
+	maxv, okMax := attrs["max"].(float64)
+	step := 1.0
+	if s, ok := attrs["step"].(float64); ok {
+		step = s
+	}
+	required, _ := attrs["required"].(bool)
+	failText, _ := attrs["failText"].(string)
+
+	entry := widget.NewEntry()
+	if ph, _ := attrs["placeHolder"].(string); ph != "" {
+		entry.SetPlaceHolder(ph)
+	}
+	parseValue := func(s string) any {
+		f, err := nf.parse(s)
+		if err != nil {
+			return nil
+		}
+		switch {
+		case integer:
+			return int64(math.Round(f))
+		case decimals >= 0:
+			p := math.Pow10(decimals)
+			return math.Round(f*p) / p
+		default:
+			return f
+		}
+	}
+	value := ui.NewUntyped()
+	entry.OnChanged = func(s string) {
+		_ = value.Set(parseValue(s))
+	}
+	entry.Validator = func(s string) error {
+		err := validateNumber(s, nf, required, integer, minv, okMin, maxv, okMax)
+		if err != nil && failText != "" {
+			return errors.New(failText)
+		}
+		return err
+	}
+	if initial, ok := attrs["initialValue"].(float64); ok {
+		entry.SetText(nf.format(initial))
+	}
+	value.AddListener(binding.NewDataListener(func() {
+		v, _ := value.Get()
+		if v == parseValue(entry.Text) {
+			return
+		}
+		switch v := v.(type) {
+		case nil:
+			entry.SetText("")
+		case float64:
+			entry.SetText(nf.format(v))
+		case int64:
+			entry.SetText(nf.format(float64(v)))
+		case string:
+			entry.SetText(v)
+		}
+	}))
+	values[outputKey] = value
+
+	add := func(delta float64) {
+		f, err := nf.parse(entry.Text)
+		if err != nil {
+			f = 0
+			if okMin && f < minv {
+				f = minv
+			}
+			delta = 0 // start with a valid value
+		}
+		f += delta
+		if okMin && f < minv {
+			f = minv
+		}
+		if okMax && f > maxv {
+			f = maxv
+		}
+		entry.SetText(nf.format(f))
+	}
+	return newButtonEntry(entry,
+		widget.NewButtonWithIcon("", theme.ContentRemoveIcon(), func() { add(-step) }),
+		widget.NewButtonWithIcon("", theme.ContentAddIcon(), func() { add(step) }),
+	)
+}
+
+func validateNumber(
+	s string, nf numberFormat, required, integer bool,
+	minv float64, okMin bool, maxv float64, okMax bool,
+) error {
+	if strings.TrimSpace(s) == "" {
+		if required {
+			return errors.New("value is required")
+		}
+		return nil
+	}
+	f, err := nf.parse(s)
+	if err != nil {
+		return fmt.Errorf("%q isn't a number", s)
+	}
+	if integer && f != math.Trunc(f) {
+		return fmt.Errorf("%q isn't an integer", s)
+	}
+	if okMin && f < minv {
+		return fmt.Errorf("%s is smaller than the minimum %s", s, nf.format(minv))
+	}
+	if okMax && f > maxv {
+		return fmt.Errorf("%s is bigger than the maximum %s", s, nf.format(maxv))
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package widget
+
+import (
+	"testing"
+)
+
+func TestNumberFormat(t *testing.T) {
+	specs := []struct {
+		name           string
+		givenLocale    string
+		givenDecimals  int
+		givenText      string
+		expectedNumber float64
+		expectedError  bool
+		expectedText   string
+	}{
+		{
+			name:           "english",
+			givenLocale:    "en-US",
+			givenDecimals:  2,
+			givenText:      "1,234.5",
+			expectedNumber: 1234.5,
+			expectedText:   "1234.50",
+		}, {
+			name:           "german",
+			givenLocale:    "de-DE",
+			givenDecimals:  2,
+			givenText:      "1.234,5",
+			expectedNumber: 1234.5,
+			expectedText:   "1234,50",
+		}, {
+			name:           "underscore",
+			givenLocale:    "fr_FR",
+			givenDecimals:  -1,
+			givenText:      " -0,25 ",
+			expectedNumber: -0.25,
+			expectedText:   "-0,25",
+		}, {
+			name:           "languageOnly",
+			givenLocale:    "en",
+			givenDecimals:  0,
+			givenText:      "42",
+			expectedNumber: 42,
+			expectedText:   "42",
+		}, {
+			name:          "noNumber",
+			givenLocale:   "en",
+			givenDecimals: -1,
+			givenText:     "4two",
+			expectedError: true,
+		}, {
+			name:          "infinity",
+			givenLocale:   "en",
+			givenDecimals: -1,
+			givenText:     "Inf",
+			expectedError: true,
+		},
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			nf := newNumberFormat(spec.givenLocale, spec.givenDecimals)
+			f, err := nf.parse(spec.givenText)
+			if spec.expectedError {
+				if err == nil {
+					t.Fatalf("expected an error but got number: %g", f)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if f != spec.expectedNumber {
+				t.Errorf("expected number %g, got %g", spec.expectedNumber, f)
+			}
+			if got := nf.format(f); got != spec.expectedText {
+				t.Errorf("expected text %q, got %q", spec.expectedText, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package widget
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"github.com/flowdev/fdialog/expr"
+	"github.com/flowdev/fdialog/run"
+	"github.com/flowdev/fdialog/ui"
+	"log"
+	"strings"
+)
+
+// -----------------------------------------------------------------------
+// Change Listeners
+//
+
+// changeNotifier is implemented by the input widgets of this package
+// (e.g. buttonEntry) that can report changes of their value.
+type changeNotifier interface {
+	addChangeListener(listener func())
+}
+
+// addChangeListener calls the listener after every change of the value of
+// the widget. Existing callbacks of the widget are kept.
+// It returns false if the widget can't report changes (e.g. a label).
+func addChangeListener(obj fyne.CanvasObject, listener func()) bool {
+	switch w := obj.(type) {
+	case changeNotifier:
+		w.addChangeListener(listener)
+	case *widget.Entry:
+		w.OnChanged = chain(w.OnChanged, listener)
+	case *widget.SelectEntry:
+		w.OnChanged = chain(w.OnChanged, listener)
+	case *widget.Select:
+		w.OnChanged = chain(w.OnChanged, listener)
+	case *widget.Check:
+		w.OnChanged = chain(w.OnChanged, listener)
+	case *widget.CheckGroup:
+		w.OnChanged = chain(w.OnChanged, listener)
+	case *widget.RadioGroup:
+		w.OnChanged = chain(w.OnChanged, listener)
+	case *widget.Slider:
+		w.OnChanged = chain(w.OnChanged, listener)
+	default:
+		return false
+	}
+	return true
+}
+
+// chain returns a callback that calls the original callback (if any)
+// and then the listener.
+func chain[T any](callback func(T), listener func()) func(T) {
+	return func(v T) {
+		if callback != nil {
+			callback(v)
+		}
+		listener()
+	}
+}
+
+// -----------------------------------------------------------------------
+// Item Rules
+//
+
+// itemRule shows or enables a form item depending on the current values.
+type itemRule struct {
+	item      *widget.FormItem
+	visibleIf *expr.Expression
+	enabledIf *expr.Expression
+	hidden    bool
+}
+
+// ruledForm is a form with all of its items (including the hidden ones).
+type ruledForm struct {
+	form      *widget.Form
+	items     []*widget.FormItem
+	hideEmpty bool
+}
+
+// itemRules are the rules of all items of a group and the forms
+// showing them. The pages of a wizard share them.
+type itemRules struct {
+	rules []*itemRule
+	forms []*ruledForm
+}
+
+// newItemRule parses the attributes "visibleIf" and "enabledIf" of an item.
+// It returns nil if the item has neither of them.
+func newItemRule(itemDescr ui.AttributesDescr, item *widget.FormItem, fullName string) *itemRule {
+	visibleIf := parseRule(itemDescr, "visibleIf", fullName)
+	enabledIf := parseRule(itemDescr, "enabledIf", fullName)
+	if visibleIf == nil && enabledIf == nil {
+		return nil
+	}
+	return &itemRule{item: item, visibleIf: visibleIf, enabledIf: enabledIf}
+}
+
+func parseRule(itemDescr ui.AttributesDescr, attr, fullName string) *expr.Expression {
+	source, ok := itemDescr[attr].(string)
+	if !ok {
+		return nil
+	}
+	e, err := expr.Parse(source)
+	if err != nil { // validated already
+		log.Printf("ERROR: for %q: %v", fullName, err)
+		return nil
+	}
+	return e
+}
+
+// addRule adds the rule of an item (if any).
+func (in *inputs) addRule(rule *itemRule) {
+	if rule != nil {
+		in.rules.rules = append(in.rules.rules, rule)
+	}
+}
+
+// addForm registers a form, so its hidden items can be removed from it.
+// If hideEmpty is true, the form is hidden if all of its items are hidden
+// (e.g. a single item in a container).
+func (in *inputs) addForm(form *widget.Form, hideEmpty bool) {
+	in.rules.forms = append(in.rules.forms, &ruledForm{form: form, items: form.Items, hideEmpty: hideEmpty})
+}
+
+// update evaluates all rules with the current values and
+// shows, hides, enables and disables the items accordingly.
+func (in *inputs) update() {
+	lookup := in.lookup
+	changed := false
+	for _, rule := range in.rules.rules {
+		if rule.visibleIf != nil {
+			hidden := !rule.visibleIf.Bool(lookup)
+			if hidden != rule.hidden {
+				rule.hidden = hidden
+				changed = true
+				if hidden {
+					rule.item.Widget.Hide()
+				} else {
+					rule.item.Widget.Show()
+				}
+			}
+		}
+		if rule.enabledIf != nil {
+			if w, ok := rule.item.Widget.(fyne.Disableable); ok {
+				enabled := rule.enabledIf.Bool(lookup)
+				if enabled == w.Disabled() {
+					changed = true // the label of the item changes its color
+					if enabled {
+						w.Enable()
+					} else {
+						w.Disable()
+					}
+				}
+			}
+		}
+	}
+	if changed {
+		for _, f := range in.rules.forms {
+			f.refresh()
+		}
+	}
+}
+
+// lookup looks up the current value of a widget of the inputs and
+// falls back to the stored values.
+func (in *inputs) lookup(ref string) (any, bool) {
+	key := ref
+	if in.group != "" {
+		key = strings.TrimPrefix(ref, in.group+".")
+	}
+	if b, ok := in.values[key]; ok {
+		return ui.BindingValue(b), true
+	}
+	return run.ValueLookup(in.group)(ref)
+}
+
+// refresh shows only the visible items of the form.
+func (f *ruledForm) refresh() {
+	items := make([]*widget.FormItem, 0, len(f.items))
+	for _, item := range f.items {
+		if item.Widget.Visible() {
+			items = append(items, item)
+		}
+	}
+	// the form only creates the rows of new items, so we have to start from scratch
+	for _, item := range f.items {
+		if v, ok := item.Widget.(fyne.Validatable); ok {
+			v.SetOnValidationChanged(nil) // the form uses the index of the item
+		}
+	}
+	f.form.Items = nil
+	f.form.Refresh()
+	f.form.Items = items
+	f.form.Refresh()
+	if !f.hideEmpty {
+		return
+	}
+	if len(items) == 0 {
+		f.form.Hide()
+	} else {
+		f.form.Show()
+	}
+}
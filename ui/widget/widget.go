@@ -3,25 +3,52 @@ package widget
 import (
 	"fmt"
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/widget"
 	"github.com/flowdev/fdialog/run"
 	"github.com/flowdev/fdialog/ui"
 	"github.com/flowdev/fdialog/valid"
 	"log"
 	"math"
-	"reflect"
 	"regexp"
 )
 
 const KeywordForm = "form"
 const KeywordFormItem = "item"
+const KeywordContainer = "container"
+const KeywordWizard = "wizard"
+const KeywordWizardPage = "page"
 
-type Creator func(attrs ui.AttributesDescr, values map[string]any, outputKey, fullName string) fyne.CanvasObject
+const KeywordRow = "row"
+
+const KeywordToolbar = "toolbar"
+
+// TypeButton is the type of button items.
+// Buttons aren't created by a Creator because they run actions.
+const TypeButton = "button"
+
+// Creator creates the widget of an item.
+// The data binding of its value (if any) is put into values with the output key.
+type Creator func(attrs ui.AttributesDescr, values map[string]binding.DataItem, outputKey, fullName string) fyne.CanvasObject
 
 var URLRegex = regexp.MustCompile(`^http(s?)://[0-9a-zA-Z]([-.\w]*[0-9a-zA-Z])*(:(0-9)*)*(/?)([a-zA-Z0-9\-.?,'/\\+&%$#_]*)?$`)
 
 var ScrollBarsRegex = regexp.MustCompile(`^both|horizontal|vertical|none$`)
 
+var TabLocationRegex = regexp.MustCompile(`^(?:top|bottom|leading|trailing)$`)
+
+var ImageFillRegex = regexp.MustCompile(`^(?:contain|stretch|original)$`)
+
+var AlignmentRegex = regexp.MustCompile(`^(?:leading|center|trailing)$`)
+
+var TextStyleRegex = regexp.MustCompile(`^(?:bold|italic|monospace)$`)
+
+var WrappingRegex = regexp.MustCompile(`^(?:off|word|break|truncate)$`)
+
+var ImportanceRegex = regexp.MustCompile(`^(?:primary|medium|low|danger|warning|success)$`)
+
+var DataOutputRegex = regexp.MustCompile(`^(?:keys|rows)$`)
+
 var widgetMap = make(map[string]Creator, 64)
 
 func RegisterAll() error {
@@ -71,6 +98,12 @@ func RegisterAll() error {
 			"hint": {
 				Validate: valid.StringValidator(1, 0, nil),
 			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"enabledIf": {
+				Validate: valid.ExpressionValidator(),
+			},
 			"disabled": {
 				Validate: valid.BoolValidator(),
 			},
@@ -115,6 +148,12 @@ func RegisterAll() error {
 			"hint": {
 				Validate: valid.StringValidator(1, 0, nil),
 			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"enabledIf": {
+				Validate: valid.ExpressionValidator(),
+			},
 			"disabled": {
 				Validate: valid.BoolValidator(),
 			},
@@ -159,6 +198,12 @@ func RegisterAll() error {
 			"hint": {
 				Validate: valid.StringValidator(1, 0, nil),
 			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"enabledIf": {
+				Validate: valid.ExpressionValidator(),
+			},
 			"disabled": {
 				Validate: valid.BoolValidator(),
 			},
@@ -203,6 +248,12 @@ func RegisterAll() error {
 			"hint": {
 				Validate: valid.StringValidator(1, 0, nil),
 			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"enabledIf": {
+				Validate: valid.ExpressionValidator(),
+			},
 			"disabled": {
 				Validate: valid.BoolValidator(),
 			},
@@ -235,6 +286,12 @@ func RegisterAll() error {
 			"hint": {
 				Validate: valid.StringValidator(1, 0, nil),
 			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"enabledIf": {
+				Validate: valid.ExpressionValidator(),
+			},
 			"disabled": {
 				Validate: valid.BoolValidator(),
 			},
@@ -269,6 +326,9 @@ func RegisterAll() error {
 			"hint": {
 				Validate: valid.StringValidator(1, 0, nil),
 			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
 			"text": {
 				Required: true,
 				Validate: valid.StringValidator(1, 0, nil),
@@ -303,6 +363,12 @@ func RegisterAll() error {
 			"hint": {
 				Validate: valid.StringValidator(1, 0, nil),
 			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"enabledIf": {
+				Validate: valid.ExpressionValidator(),
+			},
 			"disabled": {
 				Validate: valid.BoolValidator(),
 			},
@@ -342,6 +408,9 @@ func RegisterAll() error {
 			"hint": {
 				Validate: valid.StringValidator(1, 0, nil),
 			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
 			"text": {
 				Required: true,
 				Validate: valid.StringValidator(1, 0, nil),
@@ -355,6 +424,120 @@ func RegisterAll() error {
 		return err
 	}
 
+	err = ui.RegisterValidKeyword(KeywordFormItem, "image", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordFormItem),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("image"),
+			},
+			"label": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"hint": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"file": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"base64": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"svg": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"fill": {
+				Validate: valid.StringValidator(1, 0, ImageFillRegex),
+			},
+			"minWidth": {
+				Validate: valid.FloatValidator(0, math.MaxFloat32),
+			},
+			"minHeight": {
+				Validate: valid.FloatValidator(0, math.MaxFloat32),
+			},
+		},
+		Validate: ValidateImageItem,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordFormItem, "icon", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordFormItem),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("icon"),
+			},
+			"label": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"hint": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"icon": {
+				Required: true,
+				Validate: IconValidator(),
+			},
+			"size": {
+				Validate: valid.FloatValidator(1, math.MaxFloat32),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordFormItem, "label", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordFormItem),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("label"),
+			},
+			"label": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"hint": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"text": {
+				Required: true,
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"alignment": {
+				Validate: valid.StringValidator(1, 0, AlignmentRegex),
+			},
+			"style": {
+				Validate: valid.ListValidator(1, 3, valid.StringValidator(1, 0, TextStyleRegex)),
+			},
+			"wrapping": {
+				Validate: valid.StringValidator(1, 0, WrappingRegex),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
 	err = ui.RegisterValidKeyword(KeywordFormItem, "select", ui.ValidAttributesType{
 		Attributes: map[string]ui.AttributeValueType{
 			ui.AttrKeyword: {
@@ -375,6 +558,12 @@ func RegisterAll() error {
 			"hint": {
 				Validate: valid.StringValidator(1, 0, nil),
 			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"enabledIf": {
+				Validate: valid.ExpressionValidator(),
+			},
 			"disabled": {
 				Validate: valid.BoolValidator(),
 			},
@@ -415,6 +604,12 @@ func RegisterAll() error {
 			"hint": {
 				Validate: valid.StringValidator(1, 0, nil),
 			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"enabledIf": {
+				Validate: valid.ExpressionValidator(),
+			},
 			"disabled": {
 				Validate: valid.BoolValidator(),
 			},
@@ -454,6 +649,56 @@ func RegisterAll() error {
 				Required: true,
 				Validate: valid.ExactStringValidator("separator"),
 			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordFormItem, TypeButton, ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordFormItem),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator(TypeButton),
+			},
+			"label": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"hint": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"enabledIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"disabled": {
+				Validate: valid.BoolValidator(),
+			},
+			"text": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"icon": {
+				Validate: IconValidator(),
+			},
+			"importance": {
+				Validate: valid.StringValidator(1, 0, ImportanceRegex),
+			},
+			"shortcut": {
+				Validate: valid.ShortcutValidator(),
+			},
+			ui.AttrChildren: {
+				Required: true,
+				Validate: valid.ChildrenValidator(1, math.MaxInt),
+			},
 		},
 	})
 	if err != nil {
@@ -480,6 +725,12 @@ func RegisterAll() error {
 			"hint": {
 				Validate: valid.StringValidator(1, 0, nil),
 			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"enabledIf": {
+				Validate: valid.ExpressionValidator(),
+			},
 			"disabled": {
 				Validate: valid.BoolValidator(),
 			},
@@ -501,28 +752,897 @@ func RegisterAll() error {
 		return err
 	}
 
-	// -----------------------------------------------------------------------
-	// Register Runners
-	//
-
-	err = ui.RegisterRunKeyword(KeywordForm, "frm", runForm)
+	err = ui.RegisterValidKeyword(KeywordFormItem, "number", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordFormItem),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("number"),
+			},
+			ui.AttrOutputKey: {
+				Validate: valid.StringValidator(1, 0, ui.NameRegex),
+			},
+			"label": {
+				Required: true,
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"hint": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"enabledIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"disabled": {
+				Validate: valid.BoolValidator(),
+			},
+			"placeHolder": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"required": {
+				Validate: valid.BoolValidator(),
+			},
+			"min": {
+				Validate: valid.FloatValidator(-math.MaxFloat64, math.MaxFloat64),
+			},
+			"max": {
+				Validate: valid.FloatValidator(-math.MaxFloat64, math.MaxFloat64),
+			},
+			"step": {
+				Validate: valid.FloatValidator(math.SmallestNonzeroFloat64, math.MaxFloat64),
+			},
+			"decimals": {
+				Validate: valid.IntValidator(0, 15),
+			},
+			"integer": {
+				Validate: valid.BoolValidator(),
+			},
+			"initialValue": {
+				Validate: valid.FloatValidator(-math.MaxFloat64, math.MaxFloat64),
+			},
+			"locale": {
+				Validate: valid.StringValidator(2, 0, LocaleRegex),
+			},
+			"failText": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+		},
+		Validate: ValidateNumberItem,
+	})
 	if err != nil {
 		return err
 	}
 
-	// -----------------------------------------------------------------------
-	// Register Widgets
-	//
-
-	err = RegisterWidget(createEntry, "entry")
-	if err != nil {
-		return err
-	}
-	err = RegisterWidget(createMultiLineEntry, "multiLineEntry")
-	if err != nil {
-		return err
-	}
-	err = RegisterWidget(createPasswordEntry, "passwordEntry")
+	err = ui.RegisterValidKeyword(KeywordFormItem, "date", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordFormItem),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("date"),
+			},
+			ui.AttrOutputKey: {
+				Validate: valid.StringValidator(1, 0, ui.NameRegex),
+			},
+			"label": {
+				Required: true,
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"hint": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"enabledIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"disabled": {
+				Validate: valid.BoolValidator(),
+			},
+			"placeHolder": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"required": {
+				Validate: valid.BoolValidator(),
+			},
+			"min": {
+				Validate: ISODateTimeValidator("date"),
+			},
+			"max": {
+				Validate: ISODateTimeValidator("date"),
+			},
+			"initialValue": {
+				Validate: ISODateTimeValidator("date"),
+			},
+			"format": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"failText": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+		},
+		Validate: ValidateDateTimeItem,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordFormItem, "time", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordFormItem),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("time"),
+			},
+			ui.AttrOutputKey: {
+				Validate: valid.StringValidator(1, 0, ui.NameRegex),
+			},
+			"label": {
+				Required: true,
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"hint": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"enabledIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"disabled": {
+				Validate: valid.BoolValidator(),
+			},
+			"placeHolder": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"required": {
+				Validate: valid.BoolValidator(),
+			},
+			"min": {
+				Validate: ISODateTimeValidator("time"),
+			},
+			"max": {
+				Validate: ISODateTimeValidator("time"),
+			},
+			"initialValue": {
+				Validate: ISODateTimeValidator("time"),
+			},
+			"format": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"failText": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+		},
+		Validate: ValidateDateTimeItem,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordFormItem, "dateTime", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordFormItem),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("dateTime"),
+			},
+			ui.AttrOutputKey: {
+				Validate: valid.StringValidator(1, 0, ui.NameRegex),
+			},
+			"label": {
+				Required: true,
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"hint": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"enabledIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"disabled": {
+				Validate: valid.BoolValidator(),
+			},
+			"placeHolder": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"required": {
+				Validate: valid.BoolValidator(),
+			},
+			"min": {
+				Validate: ISODateTimeValidator("dateTime"),
+			},
+			"max": {
+				Validate: ISODateTimeValidator("dateTime"),
+			},
+			"initialValue": {
+				Validate: ISODateTimeValidator("dateTime"),
+			},
+			"format": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"failText": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+		},
+		Validate: ValidateDateTimeItem,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordFormItem, "list", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordFormItem),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("list"),
+			},
+			ui.AttrOutputKey: {
+				Validate: valid.StringValidator(1, 0, ui.NameRegex),
+			},
+			"label": {
+				Required: true,
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"hint": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"enabledIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"options": {
+				Validate: valid.ListValidator(1, math.MaxInt, valid.StringValidator(1, 0, nil)),
+			},
+			"columns": {
+				Validate: valid.ListValidator(1, math.MaxInt, valid.StringValidator(1, 0, nil)),
+			},
+			"file": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"header": {
+				Validate: valid.BoolValidator(),
+			},
+			"multiSelect": {
+				Validate: valid.BoolValidator(),
+			},
+			"search": {
+				Validate: valid.BoolValidator(),
+			},
+			"output": {
+				Validate: valid.StringValidator(4, 4, DataOutputRegex),
+			},
+			"initiallySelected": {
+				Validate: valid.ListValidator(1, math.MaxInt, valid.StringValidator(1, 0, nil)),
+			},
+			"required": {
+				Validate: valid.BoolValidator(),
+			},
+			"minHeight": {
+				Validate: valid.FloatValidator(0, math.MaxFloat32),
+			},
+			ui.AttrChildren: {
+				Validate: valid.ChildrenValidator(1, math.MaxInt),
+			},
+		},
+		Validate: ValidateDataItem,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordFormItem, "table", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordFormItem),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("table"),
+			},
+			ui.AttrOutputKey: {
+				Validate: valid.StringValidator(1, 0, ui.NameRegex),
+			},
+			"label": {
+				Required: true,
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"hint": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"enabledIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"columns": {
+				Validate: valid.ListValidator(1, math.MaxInt, valid.StringValidator(1, 0, nil)),
+			},
+			"file": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"header": {
+				Validate: valid.BoolValidator(),
+			},
+			"multiSelect": {
+				Validate: valid.BoolValidator(),
+			},
+			"search": {
+				Validate: valid.BoolValidator(),
+			},
+			"output": {
+				Validate: valid.StringValidator(4, 4, DataOutputRegex),
+			},
+			"initiallySelected": {
+				Validate: valid.ListValidator(1, math.MaxInt, valid.StringValidator(1, 0, nil)),
+			},
+			"required": {
+				Validate: valid.BoolValidator(),
+			},
+			"minHeight": {
+				Validate: valid.FloatValidator(0, math.MaxFloat32),
+			},
+			ui.AttrChildren: {
+				Validate: valid.ChildrenValidator(1, math.MaxInt),
+			},
+		},
+		Validate: ValidateDataItem,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordFormItem, "tree", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordFormItem),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("tree"),
+			},
+			ui.AttrOutputKey: {
+				Validate: valid.StringValidator(1, 0, ui.NameRegex),
+			},
+			"label": {
+				Required: true,
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"hint": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"enabledIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"columns": {
+				Validate: valid.ListValidator(1, math.MaxInt, valid.StringValidator(1, 0, nil)),
+			},
+			"file": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"header": {
+				Validate: valid.BoolValidator(),
+			},
+			"multiSelect": {
+				Validate: valid.BoolValidator(),
+			},
+			"search": {
+				Validate: valid.BoolValidator(),
+			},
+			"output": {
+				Validate: valid.StringValidator(4, 4, DataOutputRegex),
+			},
+			"initiallySelected": {
+				Validate: valid.ListValidator(1, math.MaxInt, valid.StringValidator(1, 0, nil)),
+			},
+			"required": {
+				Validate: valid.BoolValidator(),
+			},
+			"minHeight": {
+				Validate: valid.FloatValidator(0, math.MaxFloat32),
+			},
+			ui.AttrChildren: {
+				Validate: valid.ChildrenValidator(1, math.MaxInt),
+			},
+		},
+		Validate: ValidateDataItem,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordFormItem, "file", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordFormItem),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("file"),
+			},
+			ui.AttrOutputKey: {
+				Validate: valid.StringValidator(1, 0, ui.NameRegex),
+			},
+			"label": {
+				Required: true,
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"hint": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"enabledIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"disabled": {
+				Validate: valid.BoolValidator(),
+			},
+			"placeHolder": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"initialValue": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"required": {
+				Validate: valid.BoolValidator(),
+			},
+			"mustExist": {
+				Validate: valid.BoolValidator(),
+			},
+			"startDir": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"extensions": {
+				Validate: valid.ListValidator(1, math.MaxInt, valid.StringValidator(2, 0, ui.ExtensionRegex)),
+			},
+			"failText": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordFormItem, "folder", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordFormItem),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("folder"),
+			},
+			ui.AttrOutputKey: {
+				Validate: valid.StringValidator(1, 0, ui.NameRegex),
+			},
+			"label": {
+				Required: true,
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"hint": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"visibleIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"enabledIf": {
+				Validate: valid.ExpressionValidator(),
+			},
+			"disabled": {
+				Validate: valid.BoolValidator(),
+			},
+			"placeHolder": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"initialValue": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"required": {
+				Validate: valid.BoolValidator(),
+			},
+			"mustExist": {
+				Validate: valid.BoolValidator(),
+			},
+			"startDir": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"failText": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordRow, "", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordRow),
+			},
+			"cells": {
+				Validate: valid.ListValidator(1, math.MaxInt, SimpleValueValidator()),
+			},
+			ui.AttrChildren: {
+				Validate: valid.ChildrenValidator(1, math.MaxInt),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordContainer, "vbox", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordContainer),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("vbox"),
+			},
+			"title": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"icon": {
+				Validate: IconValidator(),
+			},
+			ui.AttrChildren: {
+				Required: true,
+				Validate: valid.ChildrenValidator(1, math.MaxInt),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordContainer, "hbox", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordContainer),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("hbox"),
+			},
+			"title": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"icon": {
+				Validate: IconValidator(),
+			},
+			ui.AttrChildren: {
+				Required: true,
+				Validate: valid.ChildrenValidator(1, math.MaxInt),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordContainer, "grid", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordContainer),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("grid"),
+			},
+			"columns": {
+				Required: true,
+				Validate: valid.IntValidator(1, math.MaxInt),
+			},
+			"title": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"icon": {
+				Validate: IconValidator(),
+			},
+			ui.AttrChildren: {
+				Required: true,
+				Validate: valid.ChildrenValidator(1, math.MaxInt),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordContainer, "border", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordContainer),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("border"),
+			},
+			"title": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"icon": {
+				Validate: IconValidator(),
+			},
+			ui.AttrChildren: {
+				Required: true,
+				Validate: valid.ChildrenValidator(1, math.MaxInt),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordContainer, "split", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordContainer),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("split"),
+			},
+			"offset": {
+				Validate: valid.FloatValidator(0.0, 1.0),
+			},
+			"vertical": {
+				Validate: valid.BoolValidator(),
+			},
+			"title": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"icon": {
+				Validate: IconValidator(),
+			},
+			ui.AttrChildren: {
+				Required: true,
+				Validate: valid.ChildrenValidator(2, 2),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordContainer, "scroll", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordContainer),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("scroll"),
+			},
+			"scroll": {
+				Validate: valid.StringValidator(1, 0, ScrollBarsRegex),
+			},
+			"title": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"icon": {
+				Validate: IconValidator(),
+			},
+			ui.AttrChildren: {
+				Required: true,
+				Validate: valid.ChildrenValidator(1, 1),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordContainer, "tabs", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordContainer),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("tabs"),
+			},
+			"tabLocation": {
+				Validate: valid.StringValidator(1, 0, TabLocationRegex),
+			},
+			"title": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"icon": {
+				Validate: IconValidator(),
+			},
+			ui.AttrChildren: {
+				Required: true,
+				Validate: valid.ChildrenValidator(1, math.MaxInt),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordContainer, "accordion", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordContainer),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("accordion"),
+			},
+			"open": {
+				Validate: valid.ListValidator(1, math.MaxInt,
+					valid.StringValidator(1, 0, ui.NameRegex)),
+			},
+			"multiOpen": {
+				Validate: valid.BoolValidator(),
+			},
+			"title": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"icon": {
+				Validate: IconValidator(),
+			},
+			ui.AttrChildren: {
+				Required: true,
+				Validate: valid.ChildrenValidator(1, math.MaxInt),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordWizard, "", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordWizard),
+			},
+			"backText": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"nextText": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"finishText": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"cancelText": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			ui.AttrChildren: {
+				Required: true,
+				Validate: valid.ChildrenValidator(3, math.MaxInt),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordWizardPage, "", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordWizardPage),
+			},
+			"title": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"skipIf": {
+				Validate: valid.StringValidator(1, 0, ui.LinkRegex),
+			},
+			"skipIfValue": {
+				Validate: valid.StringValidator(0, 0, nil),
+			},
+			ui.AttrChildren: {
+				Required: true,
+				Validate: valid.ChildrenValidator(1, math.MaxInt),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(KeywordToolbar, "", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(KeywordToolbar),
+			},
+			ui.AttrChildren: {
+				Required: true,
+				Validate: valid.ChildrenValidator(1, math.MaxInt),
+			},
+		},
+		Validate: ValidateToolbar,
+	})
+	if err != nil {
+		return err
+	}
+
+	// -----------------------------------------------------------------------
+	// Register Runners
+	//
+
+	err = ui.RegisterRunKeyword(KeywordForm, "frm", runForm)
+	if err != nil {
+		return err
+	}
+	err = ui.RegisterRunKeyword(KeywordContainer, "cont", runContainer)
+	if err != nil {
+		return err
+	}
+	err = ui.RegisterRunKeyword(KeywordWizard, "wiz", runWizard)
+	if err != nil {
+		return err
+	}
+	err = ui.RegisterRunKeyword(KeywordToolbar, "tb", runToolbar)
+	if err != nil {
+		return err
+	}
+
+	// -----------------------------------------------------------------------
+	// Register Widgets
+	//
+
+	err = RegisterWidget(createEntry, "entry")
+	if err != nil {
+		return err
+	}
+	err = RegisterWidget(createMultiLineEntry, "multiLineEntry")
+	if err != nil {
+		return err
+	}
+	err = RegisterWidget(createPasswordEntry, "passwordEntry")
 	if err != nil {
 		return err
 	}
@@ -562,11 +1682,63 @@ func RegisterAll() error {
 	if err != nil {
 		return err
 	}
+	err = RegisterWidget(createNumber, "number")
+	if err != nil {
+		return err
+	}
+	err = RegisterWidget(createDate, "date")
+	if err != nil {
+		return err
+	}
+	err = RegisterWidget(createTime, "time")
+	if err != nil {
+		return err
+	}
+	err = RegisterWidget(createDateTime, "dateTime")
+	if err != nil {
+		return err
+	}
+	err = RegisterWidget(createFile, "file")
+	if err != nil {
+		return err
+	}
+	err = RegisterWidget(createFolder, "folder")
+	if err != nil {
+		return err
+	}
+	err = RegisterWidget(createImage, "image")
+	if err != nil {
+		return err
+	}
+	err = RegisterWidget(createIcon, "icon")
+	if err != nil {
+		return err
+	}
+	err = RegisterWidget(createLabel, "label")
+	if err != nil {
+		return err
+	}
+	err = RegisterWidget(createList, "list")
+	if err != nil {
+		return err
+	}
+	err = RegisterWidget(createTable, "table")
+	if err != nil {
+		return err
+	}
+	err = RegisterWidget(createTree, "tree")
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
 func runForm(formDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) {
+	win.SetContent(createForm(formDescr, fullName, win, uiDescr))
+}
+
+func createForm(formDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) fyne.CanvasObject {
 	callback := run.BooleanCallback(formDescr[ui.AttrChildren].(ui.CommandsDescr),
 		ui.NameSubmit, ui.NameCancel, fullName, win, uiDescr)
 	group, _ := formDescr[ui.AttrName].(string) // default value
@@ -574,39 +1746,8 @@ func runForm(formDescr ui.AttributesDescr, fullName string, win fyne.Window, uiD
 		group = g
 	}
 
-	values := make(map[string]any)
-	children := formDescr[ui.AttrChildren].(ui.CommandsDescr)
-	items := make([]*widget.FormItem, 0, children.Len()-2)
-	for _, child := range children.All() {
-		if child[ui.AttrKeyword] != KeywordFormItem {
-			continue
-		}
-		name, _ := child[ui.AttrName].(string)
-		outputKey := name // default value
-		if o, ok := child[ui.AttrOutputKey].(string); ok {
-			outputKey = o
-		}
-		typ := child[ui.AttrType].(string)
-		var wdgt fyne.CanvasObject
-		if creator, ok := widgetMap[typ]; !ok {
-			log.Printf("ERROR: for %q: widget of type %q isn't registered", fullName, typ)
-			continue
-		} else {
-			wdgt = creator(child, values, outputKey, ui.FullNameFor(fullName, name))
-		}
-		if disabled, _ := child["disabled"].(bool); disabled {
-			if w, ok := wdgt.(fyne.Disableable); ok {
-				w.Disable()
-			}
-		}
-		label, _ := child["label"].(string)
-		hint, _ := child["hint"].(string)
-		items = append(items, &widget.FormItem{
-			Text:     label,
-			Widget:   wdgt,
-			HintText: hint,
-		})
-	}
+	in := newInputs(group)
+	items := createFormItems(formDescr[ui.AttrChildren].(ui.CommandsDescr), fullName, group, in, win, uiDescr)
 	form := widget.NewForm(items...)
 	if submitText, ok := formDescr["submitText"].(string); ok {
 		form.SubmitText = submitText
@@ -615,18 +1756,150 @@ func runForm(formDescr ui.AttributesDescr, fullName string, win fyne.Window, uiD
 		form.CancelText = cancelText
 	}
 	form.OnSubmit = func() {
-		// store all values:
-		for k, v := range values {
-			// we have to convert any pointer, not simply pointer to any
-			a := reflect.ValueOf(v).Elem().Interface()
-			ui.StoreValue(a, k, "", fullName, group)
+		if err := in.validate(); err != nil {
+			log.Printf("INFO: for %q: form isn't submitted: %v", fullName, err)
+			return
 		}
+		storeValues(in.values, fullName, group)
 		callback(true)
 	}
 	form.OnCancel = func() {
 		callback(false)
 	}
-	win.SetContent(form)
+	in.addForm(form, false)
+	in.update() // after setting the callbacks, so the form shows its buttons
+	ui.StoreObject(form, fullName)
+	return form
+}
+
+// Form creates a form without buttons for all items and containers of a
+// keyword that isn't a form itself (e.g. a form dialog).
+// validate validates all items and store stores their values in the group.
+func Form(
+	children ui.CommandsDescr,
+	fullName, group string,
+	win fyne.Window,
+	uiDescr ui.CommandsDescr,
+) (form *widget.Form, validate func() error, store func()) {
+	in := newInputs(group)
+	form = widget.NewForm(createFormItems(children, fullName, group, in, win, uiDescr)...)
+	in.addForm(form, false)
+	in.update()
+	return form, in.validate, func() {
+		storeValues(in.values, fullName, group)
+	}
+}
+
+// createFormItems creates the form items for all items and containers
+// of a form (or a similar keyword).
+// Other children (e.g. actions) are ignored.
+func createFormItems(
+	children ui.CommandsDescr,
+	fullName, group string,
+	in *inputs,
+	win fyne.Window,
+	uiDescr ui.CommandsDescr,
+) []*widget.FormItem {
+	items := make([]*widget.FormItem, 0, children.Len())
+	for name, child := range children.All() {
+		switch child[ui.AttrKeyword] {
+		case KeywordFormItem:
+			if item := createFormItem(child, fullName, in, win, uiDescr); item != nil {
+				items = append(items, item)
+			}
+		case KeywordContainer:
+			// the values of all items in the container are part of the form
+			cont := createContainer(child, ui.FullNameFor(fullName, name), group, in, win, uiDescr)
+			items = append(items, widget.NewFormItem("", cont))
+		}
+	}
+	return items
+}
+
+// createFormItem creates the widget for an item and wraps it into a form item.
+// It returns nil if the widget type isn't registered.
+func createFormItem(
+	itemDescr ui.AttributesDescr,
+	parent string,
+	in *inputs,
+	win fyne.Window,
+	uiDescr ui.CommandsDescr,
+) *widget.FormItem {
+	name, _ := itemDescr[ui.AttrName].(string)
+	outputKey := name // default value
+	if o, ok := itemDescr[ui.AttrOutputKey].(string); ok {
+		outputKey = o
+	}
+	typ := itemDescr[ui.AttrType].(string)
+	childName := ui.FullNameFor(parent, name)
+	var wdgt fyne.CanvasObject
+	if typ == TypeButton {
+		wdgt = createButton(itemDescr, childName, win, uiDescr)
+	} else {
+		creator, ok := widgetMap[typ]
+		if !ok {
+			log.Printf("ERROR: for %q: widget of type %q isn't registered", parent, typ)
+			return nil
+		}
+		wdgt = creator(itemDescr, in.values, outputKey, childName)
+	}
+	ui.StoreObject(wdgt, childName)
+	if b, ok := in.values[outputKey]; ok {
+		ui.StoreBinding(b, outputKey, childName, in.group)
+	}
+	if disabled, _ := itemDescr["disabled"].(bool); disabled {
+		if w, ok := wdgt.(fyne.Disableable); ok {
+			w.Disable()
+		}
+	}
+	if v, ok := wdgt.(fyne.Validatable); ok {
+		in.validatables = append(in.validatables, v)
+	}
+	addChangeListener(wdgt, in.update)
+	label, _ := itemDescr["label"].(string)
+	hint, _ := itemDescr["hint"].(string)
+	item := &widget.FormItem{
+		Text:     label,
+		Widget:   wdgt,
+		HintText: hint,
+	}
+	in.addRule(newItemRule(itemDescr, item, childName))
+	return item
+}
+
+// inputs are the input widgets of a group (e.g. of a form).
+// The values map contains the data bindings of the widgets per output key.
+type inputs struct {
+	group        string
+	values       map[string]binding.DataItem
+	validatables []fyne.Validatable
+	rules        *itemRules
+}
+
+func newInputs(group string) *inputs {
+	return &inputs{group: group, values: make(map[string]binding.DataItem), rules: &itemRules{}}
+}
+
+// validate validates all visible input widgets, so the errors are shown,
+// and returns the first error found.
+func (in *inputs) validate() error {
+	var firstErr error
+	for _, v := range in.validatables {
+		if obj, ok := v.(fyne.CanvasObject); ok && !obj.Visible() {
+			continue
+		}
+		if err := v.Validate(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// storeValues stores the current values of all widgets in the group.
+func storeValues(values map[string]binding.DataItem, fullName, group string) {
+	for k, b := range values {
+		ui.StoreValue(ui.BindingValue(b), k, "", fullName, group)
+	}
 }
 
 func RegisterWidget(w Creator, typ string) error {
@@ -0,0 +1,222 @@
+package widget
+
+import (
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"github.com/flowdev/fdialog/expr"
+	"github.com/flowdev/fdialog/run"
+	"github.com/flowdev/fdialog/ui"
+	"log"
+)
+
+type wizardPage struct {
+	descr   ui.AttributesDescr
+	title   string
+	content fyne.CanvasObject
+	in      *inputs  // only the validatables of this page; the values are shared
+	keys    []string // the output keys of the values of this page
+}
+
+type wizard struct {
+	fullName   string
+	group      string
+	pages      []*wizardPage
+	values     map[string]binding.DataItem
+	rules      *itemRules // shared by all pages, so rules can depend on other pages
+	current    int
+	history    []int // the pages shown before, so skipped pages are skipped going back, too
+	title      *widget.Label
+	back       *widget.Button
+	next       *widget.Button
+	nextText   string
+	finishText string
+	callback   func(bool)
+}
+
+func runWizard(wizDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) {
+	win.SetContent(createWizard(wizDescr, fullName, win, uiDescr))
+}
+
+// createWizard creates a sequence of pages with buttons to move between them.
+// Each page is validated before the next page is shown and
+// the values of all shown pages are stored in the group of the wizard at the end.
+func createWizard(wizDescr ui.AttributesDescr, fullName string, win fyne.Window, uiDescr ui.CommandsDescr) fyne.CanvasObject {
+	children := wizDescr[ui.AttrChildren].(ui.CommandsDescr)
+	w := &wizard{
+		fullName:   fullName,
+		values:     make(map[string]binding.DataItem),
+		rules:      &itemRules{},
+		nextText:   "Next",
+		finishText: "Finish",
+		callback:   run.BooleanCallback(children, ui.NameFinish, ui.NameCancel, fullName, win, uiDescr),
+	}
+	w.group, _ = wizDescr[ui.AttrName].(string) // default value
+	if g, ok := wizDescr[ui.AttrGroup].(string); ok {
+		w.group = g
+	}
+	if t, ok := wizDescr["nextText"].(string); ok {
+		w.nextText = t
+	}
+	if t, ok := wizDescr["finishText"].(string); ok {
+		w.finishText = t
+	}
+
+	pages := make([]fyne.CanvasObject, 0, children.Len()-2)
+	for name, child := range children.All() {
+		if child[ui.AttrKeyword] != KeywordWizardPage {
+			continue
+		}
+		pageName := ui.FullNameFor(fullName, name)
+		in := &inputs{group: w.group, values: w.values, rules: w.rules}
+		pageChildren := child[ui.AttrChildren].(ui.CommandsDescr)
+		form := widget.NewForm(createFormItems(pageChildren, pageName, w.group, in, win, uiDescr)...)
+		in.addForm(form, false)
+		form.Hide()
+		ui.StoreObject(form, pageName)
+		page := &wizardPage{descr: child, title: name, content: form, in: in, keys: newKeys(w.values, w.pages)}
+		if title, ok := child["title"].(string); ok {
+			page.title = title
+		}
+		w.pages = append(w.pages, page)
+		pages = append(pages, form)
+	}
+	if len(w.pages) == 0 {
+		log.Printf("ERROR: for %q: a wizard needs at least one page", fullName)
+		return widget.NewLabel("")
+	}
+	w.pages[0].in.update() // the rules are shared by all pages
+
+	w.title = widget.NewLabel("")
+	w.title.TextStyle = fyne.TextStyle{Bold: true}
+	cancelText := "Cancel"
+	if t, ok := wizDescr["cancelText"].(string); ok {
+		cancelText = t
+	}
+	backText := "Back"
+	if t, ok := wizDescr["backText"].(string); ok {
+		backText = t
+	}
+	cancel := widget.NewButton(cancelText, func() {
+		w.callback(false)
+	})
+	w.back = widget.NewButton(backText, w.goBack)
+	w.next = widget.NewButton(w.nextText, w.goNext)
+	w.next.Importance = widget.HighImportance
+	buttons := container.NewHBox(cancel, layout.NewSpacer(), w.back, w.next)
+
+	first := w.nextPage(-1)
+	if first < 0 {
+		first = 0 // show at least something
+	}
+	w.show(first)
+
+	wiz := container.NewBorder(w.title, buttons, nil, nil, container.NewStack(pages...))
+	ui.StoreObject(wiz, fullName)
+	return wiz
+}
+
+func (w *wizard) goNext() {
+	if err := w.pages[w.current].in.validate(); err != nil {
+		log.Printf("INFO: for %q: page %q isn't valid: %v", w.fullName, w.pages[w.current].title, err)
+		return
+	}
+	next := w.nextPage(w.current)
+	if next < 0 {
+		storeValues(w.shownValues(), w.fullName, w.group)
+		w.callback(true)
+		return
+	}
+	w.history = append(w.history, w.current)
+	w.show(next)
+}
+
+// shownValues returns the values of the pages shown to the user,
+// so values of skipped pages aren't stored.
+func (w *wizard) shownValues() map[string]binding.DataItem {
+	values := make(map[string]binding.DataItem)
+	for _, i := range append(w.history, w.current) {
+		for _, key := range w.pages[i].keys {
+			values[key] = w.values[key]
+		}
+	}
+	return values
+}
+
+// newKeys returns the keys of the values that don't belong to any of the pages.
+func newKeys(values map[string]binding.DataItem, pages []*wizardPage) []string {
+	old := make(map[string]bool)
+	for _, page := range pages {
+		for _, key := range page.keys {
+			old[key] = true
+		}
+	}
+	keys := make([]string, 0, len(values)-len(old))
+	for key := range values {
+		if !old[key] {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (w *wizard) goBack() {
+	n := len(w.history)
+	if n == 0 {
+		return
+	}
+	prev := w.history[n-1]
+	w.history = w.history[:n-1]
+	w.show(prev)
+}
+
+func (w *wizard) show(i int) {
+	w.pages[w.current].content.Hide()
+	w.current = i
+	page := w.pages[i]
+	page.content.Show()
+	w.title.SetText(fmt.Sprintf("%s (%d/%d)", page.title, i+1, len(w.pages)))
+	if len(w.history) == 0 {
+		w.back.Disable()
+	} else {
+		w.back.Enable()
+	}
+	if w.nextPage(i) < 0 {
+		w.next.SetText(w.finishText)
+	} else {
+		w.next.SetText(w.nextText)
+	}
+}
+
+// nextPage returns the index of the next page after i that isn't skipped or
+// -1 if there is none.
+func (w *wizard) nextPage(i int) int {
+	for j := i + 1; j < len(w.pages); j++ {
+		if !w.skipPage(w.pages[j]) {
+			return j
+		}
+	}
+	return -1
+}
+
+// skipPage decides with the current values if a page should be skipped.
+// Without attribute "skipIfValue" the page is skipped if the value is set
+// (true, not empty or not zero).
+func (w *wizard) skipPage(page *wizardPage) bool {
+	key, ok := page.descr["skipIf"].(string)
+	if !ok {
+		return false
+	}
+	b, ok := w.values[key]
+	if !ok {
+		return false
+	}
+	v := ui.BindingValue(b)
+	if expected, ok := page.descr["skipIfValue"].(string); ok {
+		return fmt.Sprint(v) == expected
+	}
+	return expr.Truthy(v)
+}
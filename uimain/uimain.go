@@ -8,8 +8,18 @@ import (
 	"github.com/flowdev/fdialog/valid"
 	"log"
 	"math"
+	"regexp"
 )
 
+var httpURLRegex = regexp.MustCompile(`^https?://\S+$`)
+
+var httpMethodRegex = regexp.MustCompile(`^(?:GET|POST|PUT|PATCH|DELETE)$`)
+
+var httpHeaderRegex = regexp.MustCompile(`^[-0-9A-Za-z]+:.*$`)
+
+// openURLRegex matches the URLs that the open action accepts in addition to widget.URLRegex.
+var openURLRegex = regexp.MustCompile(`^(?:file:///\S*|mailto:\S+@\S+)$`)
+
 func RegisterEverything() error {
 	err := RegisterBase()
 	if err != nil {
@@ -55,6 +65,12 @@ func RegisterBase() error {
 			"exitCode": {
 				Validate: valid.IntValidator(0, 125),
 			},
+			"timeout": {
+				Validate: valid.FloatValidator(0.1, math.MaxFloat32),
+			},
+			"countdown": {
+				Validate: valid.BoolValidator(),
+			},
 			ui.AttrChildren: {
 				Validate: valid.ChildrenValidator(0, math.MaxInt),
 			},
@@ -170,6 +186,327 @@ func RegisterBase() error {
 		return err
 	}
 
+	err = ui.RegisterValidKeyword(ui.KeywordAction, "notify", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(ui.KeywordAction),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("notify"),
+			},
+			"title": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"content": {
+				Required: true,
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(ui.KeywordAction, "exec", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(ui.KeywordAction),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("exec"),
+			},
+			"command": {
+				Required: true,
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"args": {
+				Validate: valid.ListValidator(1, math.MaxInt, valid.StringValidator(0, 0, nil)),
+			},
+			"dir": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			ui.AttrOutputKey: valid.ValidateOutputKey,
+			ui.AttrChildren: {
+				Validate: valid.ChildrenValidator(1, 2),
+			},
+		},
+		Validate: validateSuccessFailure,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(ui.KeywordAction, "http", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(ui.KeywordAction),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("http"),
+			},
+			"url": {
+				Required: true,
+				Validate: valid.StringValidator(8, 0, httpURLRegex),
+			},
+			"method": {
+				Validate: valid.StringValidator(3, 0, httpMethodRegex),
+			},
+			"headers": {
+				Validate: valid.ListValidator(1, math.MaxInt, valid.StringValidator(2, 0, httpHeaderRegex)),
+			},
+			"timeout": {
+				Validate: valid.FloatValidator(0.1, math.MaxFloat32),
+			},
+			"store": {
+				Validate: valid.ListValidator(1, math.MaxInt, valid.StringValidator(1, 0, ui.LinkRegex)),
+			},
+			ui.AttrChildren: {
+				Validate: valid.ChildrenValidator(1, 2),
+			},
+		},
+		Validate: validateSuccessFailure,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(ui.KeywordAction, "if", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(ui.KeywordAction),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("if"),
+			},
+			"condition": {
+				Required: true,
+				Validate: valid.ExpressionValidator(),
+			},
+			ui.AttrChildren: {
+				Required: true,
+				Validate: valid.ChildrenValidator(1, 2),
+			},
+		},
+		Validate: func(attrs ui.AttributesDescr, parent string) bool {
+			children, ok := attrs[ui.AttrChildren].(ui.CommandsDescr)
+			if !ok {
+				return true // reported by the children validator
+			}
+			if _, found := children.Get(ui.NameThen); !found {
+				log.Printf(`ERROR: for %q: the child %q is required`, parent, ui.NameThen)
+				ok = false
+			}
+			for name := range children.All() {
+				if name != ui.NameThen && name != ui.NameElse {
+					log.Printf(`ERROR: for %q: only the children %q and %q are allowed, got: %q`,
+						parent, ui.NameThen, ui.NameElse, name)
+					ok = false
+				}
+			}
+			return ok
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(ui.KeywordAction, "switch", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(ui.KeywordAction),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("switch"),
+			},
+			"value": {
+				Required: true,
+				Validate: valid.StringValidator(1, 0, ui.LinkRegex),
+			},
+			ui.AttrChildren: {
+				Required: true,
+				Validate: valid.ChildrenValidator(1, math.MaxInt),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(ui.KeywordAction, "set", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(ui.KeywordAction),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("set"),
+			},
+			"target": {
+				Required: true,
+				Validate: valid.StringValidator(1, 0, ui.LinkRegex),
+			},
+			"value": {
+				Validate: valid.AnyValidator(),
+			},
+			"source": {
+				Validate: valid.StringValidator(1, 0, ui.LinkRegex),
+			},
+		},
+		Validate: validateLiteralOrSource("value"),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(ui.KeywordAction, "clipboard", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(ui.KeywordAction),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("clipboard"),
+			},
+			"text": {
+				Validate: valid.StringValidator(0, 0, nil),
+			},
+			"source": {
+				Validate: valid.StringValidator(1, 0, ui.LinkRegex),
+			},
+		},
+		Validate: validateLiteralOrSource("text"),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(ui.KeywordAction, "open", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(ui.KeywordAction),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator("open"),
+			},
+			"url": {
+				Validate: validateOpenURL,
+			},
+			"file": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+		},
+		Validate: func(attrs ui.AttributesDescr, parent string) bool {
+			_, okURL := attrs["url"]
+			_, okFile := attrs["file"]
+			if okURL == okFile {
+				log.Printf(`ERROR: for %q: exactly one of the attributes "url" and "file" is required`, parent)
+				return false
+			}
+			return true
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(ui.KeywordMenu, "", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(ui.KeywordMenu),
+			},
+			"label": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			ui.AttrChildren: {
+				Required: true,
+				Validate: valid.ChildrenValidator(1, math.MaxInt),
+			},
+		},
+		Validate: validateMenu,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(ui.KeywordMenuItem, "", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(ui.KeywordMenuItem),
+			},
+			"label": {
+				Validate: valid.StringValidator(1, 0, nil),
+			},
+			"icon": {
+				Validate: widget.IconValidator(),
+			},
+			"shortcut": {
+				Validate: valid.ShortcutValidator(),
+			},
+			"disabled": {
+				Validate: valid.BoolValidator(),
+			},
+			ui.AttrChildren: {
+				Required: true,
+				Validate: valid.ChildrenValidator(1, math.MaxInt),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(ui.KeywordMenuItem, run.TypeSeparator, ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(ui.KeywordMenuItem),
+			},
+			ui.AttrType: {
+				Required: true,
+				Validate: valid.ExactStringValidator(run.TypeSeparator),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = ui.RegisterValidKeyword(ui.KeywordShortcut, "", ui.ValidAttributesType{
+		Attributes: map[string]ui.AttributeValueType{
+			ui.AttrKeyword: {
+				Required: true,
+				Validate: valid.ExactStringValidator(ui.KeywordShortcut),
+			},
+			"keys": {
+				Required: true,
+				Validate: valid.ShortcutValidator(),
+			},
+			ui.AttrChildren: {
+				Required: true,
+				Validate: valid.ChildrenValidator(1, math.MaxInt),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
 	// -----------------------------------------------------------------------
 	// Register Runners
 	//
@@ -187,6 +524,14 @@ func RegisterBase() error {
 	if err != nil {
 		return err
 	}
+	err = ui.RegisterRunKeyword(ui.KeywordMenu, "mnu", run.Menu)
+	if err != nil {
+		return err
+	}
+	err = ui.RegisterRunKeyword(ui.KeywordShortcut, "sc", run.Shortcut)
+	if err != nil {
+		return err
+	}
 
 	// Actions:
 	err = ui.RegisterAction("exit", run.Exit)
@@ -205,5 +550,100 @@ func RegisterBase() error {
 	if err != nil {
 		return err
 	}
+	err = ui.RegisterAction("notify", run.Notify)
+	if err != nil {
+		return err
+	}
+	err = ui.RegisterAction("exec", run.Exec)
+	if err != nil {
+		return err
+	}
+	err = ui.RegisterAction("http", run.HTTP)
+	if err != nil {
+		return err
+	}
+	err = ui.RegisterAction("if", run.If)
+	if err != nil {
+		return err
+	}
+	err = ui.RegisterAction("switch", run.Switch)
+	if err != nil {
+		return err
+	}
+	err = ui.RegisterAction("set", run.Set)
+	if err != nil {
+		return err
+	}
+	err = ui.RegisterAction("clipboard", run.Clipboard)
+	if err != nil {
+		return err
+	}
+	err = ui.RegisterAction("open", run.Open)
+	if err != nil {
+		return err
+	}
 	return nil
 }
+
+// validateSuccessFailure checks that the children of an action are only
+// the actions "success" and "failure".
+func validateSuccessFailure(attrs ui.AttributesDescr, parent string) bool {
+	children, ok := attrs[ui.AttrChildren].(ui.CommandsDescr)
+	if !ok {
+		return true
+	}
+	for name := range children.All() {
+		if name != ui.NameSuccess && name != ui.NameFailure {
+			log.Printf(`ERROR: for %q: only the children %q and %q are allowed, got: %q`,
+				parent, ui.NameSuccess, ui.NameFailure, name)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// validateMenu checks that the children of a menu are only
+// menu items and nested menus.
+func validateMenu(attrs ui.AttributesDescr, parent string) bool {
+	children, ok := attrs[ui.AttrChildren].(ui.CommandsDescr)
+	if !ok {
+		return true // reported by the children validator
+	}
+	for name, child := range children.All() {
+		if keyword := child[ui.AttrKeyword]; keyword != ui.KeywordMenu && keyword != ui.KeywordMenuItem {
+			log.Printf(`ERROR: for %q: only menu items and menus are allowed as children, got: %q`,
+				ui.FullNameFor(parent, name), keyword)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// validateOpenURL checks that the value is a URL like for hyperlinks
+// or a file or mailto URL.
+func validateOpenURL(v any, strict bool, parent string) (any, bool) {
+	s, ok := valid.StringValidator(8, 0, nil)(v, strict, parent)
+	if !ok {
+		return s, false
+	}
+	if !widget.URLRegex.MatchString(s.(string)) && !openURLRegex.MatchString(s.(string)) {
+		log.Printf("ERROR: for %q: expecting an HTTP(S), file or mailto URL, got %q", parent, s)
+		return s, false
+	}
+	return s, true
+}
+
+// validateLiteralOrSource checks that exactly one of the attributes with
+// the literal value and "source" is given.
+func validateLiteralOrSource(literal string) func(attrs ui.AttributesDescr, parent string) bool {
+	return func(attrs ui.AttributesDescr, parent string) bool {
+		_, okLiteral := attrs[literal]
+		_, okSource := attrs["source"]
+		if okLiteral == okSource {
+			log.Printf(`ERROR: for %q: exactly one of the attributes %q and "source" is required`,
+				parent, literal)
+			return false
+		}
+		return true
+	}
+}
@@ -0,0 +1,667 @@
+// Package uitest runs test scripts against UI descriptions.
+// A UI description is run with the software test driver of Fyne and
+// the steps of the script simulate the user.
+// Finally, the exit code and the data written by the write action are
+// compared with the expected values.
+//
+// A test script is a YAML file like this:
+//
+//	file: register.uidl   # relative to the script
+//	format: uidl          # optional, 'uidl' is the default
+//	steps:
+//	  - type: {item: main.registerForm.name, text: Jane Doe}
+//	  - select: {item: main.registerForm.gender, option: female}
+//	  - check: {item: main.registerForm.newsletter, checked: true}
+//	  - submit: main.registerForm
+//	expect:
+//	  exitCode: 0
+//	  output:
+//	    - {name: Jane Doe, gender: female, newsletter: true}
+//
+// Items and forms are referenced by their full name path or their ID.
+// The steps `click` (button text), `cancel` (form) and `key` (key name,
+// e.g. `Escape`, or key combination of a shortcut, e.g. `Ctrl+S`) are supported, too.
+// The step `menu` chooses an item of the main menu by the labels of the menu
+// and the (nested) menu items (e.g. `[File, Save]`).
+// For lists, tables and trees the option of the `select` step is the key of a row.
+// Standard input for progress dialogs is given with `input` (and `inputOpen`)
+// and the step `wait` (a duration like `2s`) waits until the app exits.
+// The steps change the UI with ui.Do, like the actions running in the background
+// (e.g. exec), and these actions are finished before the results are collected.
+// Notifications of the notify action are expected with `notifications`
+// (a list of objects with `title` and `content`).
+// The content of the clipboard (e.g. of the clipboard action) is expected with `clipboard`
+// and the URLs opened by the open action with `opened` (a list of strings).
+// A UI description without main window can consist only of actions.
+package uitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"gopkg.in/yaml.v3"
+
+	"github.com/flowdev/fdialog/parse"
+	"github.com/flowdev/fdialog/run"
+	"github.com/flowdev/fdialog/ui"
+	"github.com/flowdev/fdialog/valid"
+)
+
+// Spec is a test script for a single UI description.
+type Spec struct {
+	File   string `yaml:"file"`
+	Format string `yaml:"format"`
+	Input  string `yaml:"input"` // standard input for progress dialogs
+	// InputOpen keeps standard input open after the input until the end of the script.
+	InputOpen bool   `yaml:"inputOpen"`
+	Steps     []Step `yaml:"steps"`
+	Expect    Expect `yaml:"expect"`
+}
+
+// Step is a single user interaction.
+// Exactly one of its fields has to be set.
+type Step struct {
+	Type   *TypeStep   `yaml:"type"`
+	Select *SelectStep `yaml:"select"`
+	Check  *CheckStep  `yaml:"check"`
+	Click  string      `yaml:"click"`
+	Submit string      `yaml:"submit"`
+	Cancel string      `yaml:"cancel"`
+	Key    string      `yaml:"key"`
+	Menu   []string    `yaml:"menu"` // labels of the menu and the (nested) menu items
+	Wait   string      `yaml:"wait"` // duration like "200ms"
+}
+
+// TypeStep types text into an entry.
+type TypeStep struct {
+	Item string `yaml:"item"`
+	Text string `yaml:"text"`
+}
+
+// SelectStep selects an option of a select, radio group or check group
+// or the row with the key (option) of a list, table or tree.
+type SelectStep struct {
+	Item   string `yaml:"item"`
+	Option string `yaml:"option"`
+}
+
+// CheckStep checks or unchecks a check box.
+type CheckStep struct {
+	Item    string `yaml:"item"`
+	Checked bool   `yaml:"checked"`
+}
+
+// Expect contains the expected results of a test script.
+// A nil ExitCode means that the app must not exit.
+// Output contains one JSON object per write action.
+type Expect struct {
+	ExitCode      *int           `yaml:"exitCode"`
+	Output        []any          `yaml:"output"`
+	Notifications []Notification `yaml:"notifications"`
+	Clipboard     *string        `yaml:"clipboard"`
+	Opened        []string       `yaml:"opened"`
+}
+
+// Notification is a desktop notification sent by the notify action.
+type Notification struct {
+	Title   string `yaml:"title"`
+	Content string `yaml:"content"`
+}
+
+// Result is the actual result of running a test script.
+type Result struct {
+	Exited        bool
+	ExitCode      int
+	Output        []any
+	Notifications []Notification
+	Clipboard     string
+	Opened        []string
+}
+
+// RunFile loads a test script from a YAML file and runs it.
+// An error is returned if the script can't be run or
+// the results don't match the expectations.
+func RunFile(fileName string) error {
+	spec, err := LoadSpec(fileName)
+	if err != nil {
+		return err
+	}
+	return Run(spec, filepath.Dir(fileName))
+}
+
+// LoadSpec loads a test script from a YAML file.
+func LoadSpec(fileName string) (*Spec, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read test script: %w", err)
+	}
+	spec := &Spec{}
+	if err = yaml.Unmarshal(data, spec); err != nil {
+		return nil, fmt.Errorf("unable to parse test script %q: %w", fileName, err)
+	}
+	if spec.File == "" {
+		return nil, fmt.Errorf("test script %q: the UI description file is missing", fileName)
+	}
+	for i, step := range spec.Steps {
+		if n := step.fieldCount(); n != 1 {
+			return nil, fmt.Errorf("test script %q: step %d: exactly one field has to be set, got: %d",
+				fileName, i+1, n)
+		}
+	}
+	return spec, nil
+}
+
+// fieldCount returns the number of fields that are set in the step.
+func (s Step) fieldCount() int {
+	n := 0
+	for _, set := range []bool{
+		s.Type != nil, s.Select != nil, s.Check != nil,
+		s.Click != "", s.Submit != "", s.Cancel != "",
+		s.Key != "", len(s.Menu) > 0, s.Wait != "",
+	} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+// Run runs a test script.
+// The UI description file of the script is relative to dir.
+// An error is returned if the script can't be run or
+// the results don't match the expectations.
+func Run(spec *Spec, dir string) error {
+	result, err := Execute(spec, dir)
+	if err != nil {
+		return err
+	}
+	return compare(spec.Expect, result)
+}
+
+// Execute runs the UI description of a test script and simulates the
+// steps of the script.
+// The actual results are returned without comparing them to the expectations.
+func Execute(spec *Spec, dir string) (*Result, error) {
+	fileName := spec.File
+	if !filepath.IsAbs(fileName) {
+		fileName = filepath.Join(dir, fileName)
+	}
+	format := spec.Format
+	if format == "" {
+		format = "uidl"
+	}
+
+	ui.Do(func() {
+		ui.DeleteAllIDs()
+		ui.DeleteAllValues()
+		ui.DeleteAllObjects()
+		ui.StoreExitCode(0)
+		ui.SetBaseDir(filepath.Dir(fileName))
+	})
+	defer ui.Do(func() { ui.SetBaseDir("") })
+
+	uiDescr, err := parseFile(fileName, format)
+	if err != nil {
+		return nil, err
+	}
+	mainWin, ok := uiDescr.Get(ui.WinMain)
+	if !ok && !run.OnlyActions(uiDescr) {
+		return nil, errors.New("unable to find main window in UI description")
+	}
+	runWin, ok2 := ui.RunFuncForKeyword(ui.KeywordWindow)
+	if !ok2 {
+		return nil, errors.New("unable to get run function for keyword 'window'")
+	}
+
+	result := &Result{}
+	output := &bytes.Buffer{}
+	testApp := test.NewApp()
+	var mu sync.Mutex // progress dialogs exit from their own goroutine
+	exitChan := make(chan struct{})
+	exit := func(code int) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !result.Exited {
+			close(exitChan)
+		}
+		result.Exited = true
+		result.ExitCode = code
+	}
+	exited := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return result.Exited
+	}
+	inputReader, inputWriter := io.Pipe()
+	go func() {
+		_, _ = io.WriteString(inputWriter, spec.Input)
+		if !spec.InputOpen {
+			_ = inputWriter.Close()
+		}
+	}()
+	ui.Do(func() {
+		ui.SetApp(testApp)
+		ui.SetExitFunc(exit)
+		run.SetOutput(output)
+		run.SetInput(inputReader)
+		run.SetNotifier(func(n *fyne.Notification) {
+			mu.Lock()
+			defer mu.Unlock()
+			result.Notifications = append(result.Notifications, Notification{Title: n.Title, Content: n.Content})
+		})
+		run.SetOpener(func(u *url.URL) error {
+			mu.Lock()
+			defer mu.Unlock()
+			result.Opened = append(result.Opened, u.String())
+			return nil
+		})
+	})
+	defer func() {
+		_ = inputWriter.Close()
+		run.WaitForBackground() // nothing of this script may change the next one
+		ui.Do(func() {
+			run.SetInput(nil)
+			run.SetOutput(nil)
+			run.SetNotifier(nil)
+			run.SetOpener(nil)
+			ui.SetExitFunc(nil)
+			ui.SetApp(nil)
+		})
+	}()
+
+	if !ok { // only actions
+		run.TopLevelActions(uiDescr)
+		return finish(result, output)
+	}
+
+	var win fyne.Window
+	ui.Do(func() {
+		runWin(mainWin, ui.WinMain, nil, uiDescr)
+		if windows := testApp.Driver().AllWindows(); len(windows) >= 2 { // the test driver always creates a dummy window
+			win = windows[len(windows)-1]
+		}
+	})
+	if win == nil {
+		return nil, errors.New("no window has been created")
+	}
+
+	for i, step := range spec.Steps {
+		if exited() {
+			return nil, fmt.Errorf("step %d: the app has exited already", i+1)
+		}
+		if step.Wait != "" {
+			if err = wait(step.Wait, exitChan); err != nil {
+				return nil, fmt.Errorf("step %d: %w", i+1, err)
+			}
+			continue
+		}
+		ui.Do(func() { err = runStep(step, win) })
+		if err != nil {
+			return nil, fmt.Errorf("step %d: %w", i+1, err)
+		}
+	}
+
+	run.WaitForBackground()
+	ui.Do(func() { result.Clipboard = win.Clipboard().Content() })
+	return finish(result, output)
+}
+
+// finish adds the output to the result.
+func finish(result *Result, output *bytes.Buffer) (*Result, error) {
+	var err error
+	ui.Do(func() { result.Output, err = decodeOutput(output.Bytes()) })
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func parseFile(fileName, format string) (ui.CommandsDescr, error) {
+	rd, err := os.Open(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open UI description file: %w", err)
+	}
+	defer rd.Close()
+
+	uiDescr, err := parse.UIDescription(rd, fileName, format)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse UI description:\n%w", err)
+	}
+	if ok := valid.UIDescription(uiDescr, true); !ok {
+		return nil, fmt.Errorf("UI description %q is invalid", fileName)
+	}
+	return uiDescr, nil
+}
+
+// ---------------------------------------------------------------------------
+// Steps
+//
+
+func runStep(step Step, win fyne.Window) error {
+	switch {
+	case step.Type != nil:
+		return typeText(step.Type)
+	case step.Select != nil:
+		return selectOption(step.Select)
+	case step.Check != nil:
+		return check(step.Check)
+	case step.Click != "":
+		return click(step.Click, win)
+	case step.Submit != "":
+		return tapFormButton(step.Submit, true)
+	case step.Cancel != "":
+		return tapFormButton(step.Cancel, false)
+	case len(step.Menu) > 0:
+		return chooseMenuItem(step.Menu, win)
+	case step.Key != "":
+		return typeKey(step.Key, win)
+	default:
+		return errors.New("empty step")
+	}
+}
+
+// wait waits for the duration or until the app has exited.
+func wait(duration string, exitChan <-chan struct{}) error {
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		return fmt.Errorf("unable to parse wait duration: %w", err)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-exitChan:
+	case <-timer.C:
+	}
+	return nil
+}
+
+func typeText(step *TypeStep) error {
+	obj, err := findObject(step.Item)
+	if err != nil {
+		return err
+	}
+	focusable, ok := obj.(fyne.Focusable)
+	if !ok {
+		return fmt.Errorf("unable to type into item %q of type %T", step.Item, obj)
+	}
+	test.Type(focusable, step.Text)
+	return nil
+}
+
+func selectOption(step *SelectStep) error {
+	obj, err := findObject(step.Item)
+	if err != nil {
+		return err
+	}
+	switch w := obj.(type) {
+	case *widget.Select:
+		if !slices.Contains(w.Options, step.Option) {
+			return fmt.Errorf("item %q has no option %q", step.Item, step.Option)
+		}
+		w.SetSelected(step.Option)
+	case *widget.RadioGroup:
+		if !slices.Contains(w.Options, step.Option) {
+			return fmt.Errorf("item %q has no option %q", step.Item, step.Option)
+		}
+		w.SetSelected(step.Option)
+	case *widget.CheckGroup:
+		if !slices.Contains(w.Options, step.Option) {
+			return fmt.Errorf("item %q has no option %q", step.Item, step.Option)
+		}
+		if !slices.Contains(w.Selected, step.Option) {
+			w.SetSelected(append(slices.Clone(w.Selected), step.Option))
+		}
+	case interface{ Select(key string) error }: // list, table and tree
+		if err = w.Select(step.Option); err != nil {
+			return fmt.Errorf("item %q: %w", step.Item, err)
+		}
+	default:
+		return fmt.Errorf("unable to select an option of item %q of type %T", step.Item, obj)
+	}
+	return nil
+}
+
+func check(step *CheckStep) error {
+	obj, err := findObject(step.Item)
+	if err != nil {
+		return err
+	}
+	box, ok := obj.(*widget.Check)
+	if !ok {
+		return fmt.Errorf("unable to check item %q of type %T", step.Item, obj)
+	}
+	box.SetChecked(step.Checked)
+	return nil
+}
+
+func click(text string, win fyne.Window) error {
+	objs := []fyne.CanvasObject{win.Canvas().Content()}
+	objs = append(objs, win.Canvas().Overlays().List()...)
+	for i := len(objs) - 1; i >= 0; i-- { // top most overlay first
+		if button := findButton(objs[i], text); button != nil {
+			return tapButton(button)
+		}
+	}
+	return fmt.Errorf("unable to find a button with text %q", text)
+}
+
+func tapFormButton(name string, submit bool) error {
+	obj, err := findObject(name)
+	if err != nil {
+		return err
+	}
+	form, ok := obj.(*widget.Form)
+	if !ok {
+		return fmt.Errorf("object %q is not a form but a %T", name, obj)
+	}
+	text := form.CancelText
+	if submit {
+		text = form.SubmitText
+	}
+	if text == "" { // we have to use the default texts of Fyne
+		text = "Cancel"
+		if submit {
+			text = "Submit"
+		}
+	}
+	button := findButton(form, text)
+	if button == nil {
+		return fmt.Errorf("unable to find button %q of form %q", text, name)
+	}
+	return tapButton(button)
+}
+
+func tapButton(button *widget.Button) error {
+	if button.Disabled() {
+		return fmt.Errorf("button %q is disabled", button.Text)
+	}
+	test.Tap(button)
+	return nil
+}
+
+func typeKey(key string, win fyne.Window) error {
+	if strings.Contains(key, "+") { // key combination like Ctrl+S
+		return ui.TypeShortcut(win, key)
+	}
+	event := &fyne.KeyEvent{Name: fyne.KeyName(key)}
+	if focused := win.Canvas().Focused(); focused != nil {
+		focused.TypedKey(event)
+		return nil
+	}
+	onTypedKey := win.Canvas().OnTypedKey()
+	if onTypedKey == nil {
+		return fmt.Errorf("nothing handles key %q", key)
+	}
+	onTypedKey(event)
+	return nil
+}
+
+func chooseMenuItem(labels []string, win fyne.Window) error {
+	mainMenu := win.MainMenu()
+	if mainMenu == nil {
+		return errors.New("the window has no main menu")
+	}
+	var items []*fyne.MenuItem
+	for _, menu := range mainMenu.Items {
+		if menu.Label == labels[0] {
+			items = menu.Items
+			break
+		}
+	}
+	if items == nil {
+		return fmt.Errorf("unable to find menu %q", labels[0])
+	}
+	for i, label := range labels[1:] {
+		j := slices.IndexFunc(items, func(item *fyne.MenuItem) bool {
+			return item.Label == label
+		})
+		if j < 0 {
+			return fmt.Errorf("unable to find menu item %q", strings.Join(labels[:i+2], " > "))
+		}
+		item := items[j]
+		if i == len(labels)-2 { // last label
+			if item.Disabled {
+				return fmt.Errorf("menu item %q is disabled", label)
+			}
+			if item.Action == nil {
+				return fmt.Errorf("menu item %q has no action", label)
+			}
+			item.Action()
+			return nil
+		}
+		if item.ChildMenu == nil {
+			return fmt.Errorf("menu item %q has no child menu", label)
+		}
+		items = item.ChildMenu.Items
+	}
+	return fmt.Errorf("no menu item given for menu %q", labels[0])
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+//
+
+func findObject(name string) (fyne.CanvasObject, error) {
+	if obj, ok := ui.GetObjectByFullName(name); ok {
+		return obj, nil
+	}
+	if obj, ok := ui.GetObjectByID(name); ok {
+		return obj, nil
+	}
+	return nil, fmt.Errorf("unable to find item %q", name)
+}
+
+// findButton searches the object tree for a button with the given text.
+func findButton(obj fyne.CanvasObject, text string) *widget.Button {
+	if obj == nil || !obj.Visible() {
+		return nil
+	}
+	if button, ok := obj.(*widget.Button); ok {
+		if button.Text == text {
+			return button
+		}
+		return nil
+	}
+
+	var children []fyne.CanvasObject
+	switch o := obj.(type) {
+	case *fyne.Container:
+		children = o.Objects
+	case fyne.Widget:
+		children = test.WidgetRenderer(o).Objects()
+	}
+	for _, child := range children {
+		if button := findButton(child, text); button != nil {
+			return button
+		}
+	}
+	return nil
+}
+
+func decodeOutput(data []byte) ([]any, error) {
+	var output []any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for dec.More() {
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			return nil, fmt.Errorf("unable to decode output: %w", err)
+		}
+		output = append(output, v)
+	}
+	return output, nil
+}
+
+func compare(expect Expect, result *Result) error {
+	var errs []error
+	switch {
+	case expect.ExitCode == nil && result.Exited:
+		errs = append(errs, fmt.Errorf("expected the app not to exit, but it exited with code %d",
+			result.ExitCode))
+	case expect.ExitCode != nil && !result.Exited:
+		errs = append(errs, fmt.Errorf("expected the app to exit with code %d, but it didn't exit",
+			*expect.ExitCode))
+	case expect.ExitCode != nil && *expect.ExitCode != result.ExitCode:
+		errs = append(errs, fmt.Errorf("expected exit code %d, got %d",
+			*expect.ExitCode, result.ExitCode))
+	}
+
+	expectedOutput, err := normalize(expect.Output)
+	if err != nil {
+		return err
+	}
+	if len(expectedOutput) != 0 || len(result.Output) != 0 {
+		if !reflect.DeepEqual(expectedOutput, result.Output) {
+			errs = append(errs, fmt.Errorf("expected output:\n%s\ngot:\n%s",
+				toJSON(expectedOutput), toJSON(result.Output)))
+		}
+	}
+	if !slices.Equal(expect.Notifications, result.Notifications) {
+		errs = append(errs, fmt.Errorf("expected notifications:\n%+v\ngot:\n%+v",
+			expect.Notifications, result.Notifications))
+	}
+	if !slices.Equal(expect.Opened, result.Opened) {
+		errs = append(errs, fmt.Errorf("expected opened URLs:\n%q\ngot:\n%q",
+			expect.Opened, result.Opened))
+	}
+	if expect.Clipboard != nil && *expect.Clipboard != result.Clipboard {
+		errs = append(errs, fmt.Errorf("expected clipboard content %q, got %q",
+			*expect.Clipboard, result.Clipboard))
+	}
+	return errors.Join(errs...)
+}
+
+// normalize converts the expected values from YAML to the same types
+// the actual output has after decoding the JSON.
+func normalize(values []any) ([]any, error) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert expected output: %w", err)
+	}
+	var result []any
+	if err = json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("unable to convert expected output: %w", err)
+	}
+	return result, nil
+}
+
+func toJSON(values []any) string {
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%#v", values)
+	}
+	return string(data)
+}
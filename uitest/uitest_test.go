@@ -0,0 +1,167 @@
+package uitest_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/flowdev/fdialog/uimain"
+	"github.com/flowdev/fdialog/uitest"
+)
+
+func TestRunFile(t *testing.T) {
+	_ = uimain.RegisterEverything()
+
+	examples, err := filepath.Glob(filepath.Join("..", "examples", "*.test.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	specs := []struct {
+		name                  string
+		givenFile             string
+		expectedErrorContains string // an error is expected if not empty
+	}{
+		{
+			name:      "cancelForm",
+			givenFile: "testdata/register-cancel.test.yaml",
+		}, {
+			name:                  "invalidForm",
+			givenFile:             "testdata/register-invalid.test.yaml",
+			expectedErrorContains: `step 4: button "Submit" is disabled`,
+		}, {
+			name:                  "wrongOutput",
+			givenFile:             "testdata/register-wrong-output.test.yaml",
+			expectedErrorContains: "expected output:",
+		}, {
+			name:      "confirmWithKey",
+			givenFile: "testdata/confirm-key.test.yaml",
+		}, {
+			name:      "invalidNestedItem",
+			givenFile: "testdata/settings-invalid.test.yaml",
+		}, {
+			name:      "wizardSkipsPage",
+			givenFile: "testdata/install-typical.test.yaml",
+		}, {
+			name:                  "dateOutOfRange",
+			givenFile:             "testdata/appointment-invalid.test.yaml",
+			expectedErrorContains: `step 2: button "Submit" is disabled`,
+		}, {
+			name:      "cancelProgress",
+			givenFile: "testdata/progress-cancel.test.yaml",
+		}, {
+			name:                  "emptyRequiredEntry",
+			givenFile:             "testdata/prompt-empty.test.yaml",
+			expectedErrorContains: `step 1: button "Unlock" is disabled`,
+		}, {
+			name:      "cancelEntryWithKey",
+			givenFile: "testdata/prompt-escape.test.yaml",
+		}, {
+			name:                  "wrongFileExtension",
+			givenFile:             "testdata/backup-wrong-extension.test.yaml",
+			expectedErrorContains: `step 3: button "Submit" is disabled`,
+		}, {
+			name:                  "noFilesToOpen",
+			givenFile:             "testdata/open-files-empty.test.yaml",
+			expectedErrorContains: `step 2: button "Upload" is disabled`,
+		}, {
+			name:      "cancelOpenFiles",
+			givenFile: "testdata/open-files-cancel.test.yaml",
+		}, {
+			name:      "cancelOpenFilesWithKey",
+			givenFile: "testdata/open-files-escape.test.yaml",
+		}, {
+			name:      "cancelCustomWithKey",
+			givenFile: "testdata/save-escape.test.yaml",
+		}, {
+			name:      "dialogTimeout",
+			givenFile: "testdata/timeout-default.test.yaml",
+		}, {
+			name:      "windowTimeout",
+			givenFile: "testdata/timeout-window.test.yaml",
+		}, {
+			name:      "timeoutStoppedByClose",
+			givenFile: "testdata/timeout-stopped.test.yaml",
+		}, {
+			name:      "notifyFromWindow",
+			givenFile: "testdata/notify-window.test.yaml",
+		}, {
+			name:      "execSuccess",
+			givenFile: "testdata/exec-success.test.yaml",
+		}, {
+			name:      "execFailure",
+			givenFile: "testdata/exec-failure.test.yaml",
+		}, {
+			name:      "execTopLevel",
+			givenFile: "testdata/exec-top.test.yaml",
+		}, {
+			name:      "switchOnValue",
+			givenFile: "testdata/survey-male.test.yaml",
+		}, {
+			name:      "ifElse",
+			givenFile: "testdata/survey-young.test.yaml",
+		}, {
+			name:      "switchDefault",
+			givenFile: "testdata/survey-default.test.yaml",
+		}, {
+			name:      "hiddenItemNotValidated",
+			givenFile: "testdata/account-private.test.yaml",
+		}, {
+			name:                  "shownItemValidated",
+			givenFile:             "testdata/account-hidden-invalid.test.yaml",
+			expectedErrorContains: "expected the app to exit with code 0, but it didn't exit",
+		}, {
+			name:      "setAndCopyValues",
+			givenFile: "testdata/set-reset.test.yaml",
+		}, {
+			name:      "openFileAndURL",
+			givenFile: "testdata/open-log.test.yaml",
+		}, {
+			name:      "disabledButtonShortcut",
+			givenFile: "testdata/tools-disabled.test.yaml",
+		}, {
+			name:      "menuAndEscapeShortcut",
+			givenFile: "testdata/notes-escape.test.yaml",
+		}, {
+			name:      "dialogKeysBeforeShortcut",
+			givenFile: "testdata/shortcut-dialog.test.yaml",
+		}, {
+			name:                  "unknownMenuItem",
+			givenFile:             "testdata/notes-missing-item.test.yaml",
+			expectedErrorContains: `step 1: unable to find menu item "File > Print"`,
+		}, {
+			name:                  "ambiguousStep",
+			givenFile:             "testdata/step-ambiguous.test.yaml",
+			expectedErrorContains: "step 1: exactly one field has to be set, got: 2",
+		}, {
+			name:                  "emptyStep",
+			givenFile:             "testdata/step-empty.test.yaml",
+			expectedErrorContains: "step 1: exactly one field has to be set, got: 0",
+		}, {
+			name:                  "missingScript",
+			givenFile:             "testdata/missing.test.yaml",
+			expectedErrorContains: "unable to read test script",
+		},
+	}
+	for _, example := range examples {
+		specs = append(specs, struct {
+			name                  string
+			givenFile             string
+			expectedErrorContains string
+		}{name: filepath.Base(example), givenFile: example})
+	}
+
+	for _, spec := range specs {
+		t.Run(spec.name, func(t *testing.T) {
+			err := uitest.RunFile(spec.givenFile)
+			switch {
+			case spec.expectedErrorContains == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case spec.expectedErrorContains != "" && err == nil:
+				t.Errorf("expected an error containing %q but got none", spec.expectedErrorContains)
+			case spec.expectedErrorContains != "" && !strings.Contains(err.Error(), spec.expectedErrorContains):
+				t.Errorf("expected an error containing %q, got: %v", spec.expectedErrorContains, err)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"strconv"
 
+	"github.com/flowdev/fdialog/expr"
 	"github.com/flowdev/fdialog/ui"
 )
 
@@ -166,6 +167,46 @@ func BoolValidator() ui.AttributeValidator {
 	}
 }
 
+// AnyValidator accepts a value of any data type (e.g. a literal value of an action).
+func AnyValidator() ui.AttributeValidator {
+	return func(v any, strict bool, parent string) (any, bool) {
+		return v, true
+	}
+}
+
+// ShortcutValidator checks that the value is a key combination like "Ctrl+S" or "Escape".
+func ShortcutValidator() ui.AttributeValidator {
+	validateString := StringValidator(1, 0, nil)
+	return func(v any, strict bool, parent string) (any, bool) {
+		s, ok := validateString(v, strict, parent)
+		if !ok {
+			return s, false
+		}
+		if _, err := ui.ParseShortcut(s.(string)); err != nil {
+			log.Printf("ERROR: for %q: %v", parent, err)
+			return s, false
+		}
+		return s, true
+	}
+}
+
+// ExpressionValidator checks that the value is a valid expression
+// (e.g. `form.gender == "female" && form.age >= 18`).
+func ExpressionValidator() ui.AttributeValidator {
+	validateString := StringValidator(1, 0, nil)
+	return func(v any, strict bool, parent string) (any, bool) {
+		s, ok := validateString(v, strict, parent)
+		if !ok {
+			return s, false
+		}
+		if _, err := expr.Parse(s.(string)); err != nil {
+			log.Printf("ERROR: for %q: %v", parent, err)
+			return s, false
+		}
+		return s, true
+	}
+}
+
 func ListValidator(minLen, maxLen int, subValidator ui.AttributeValidator) ui.AttributeValidator {
 	return func(v any, strict bool, parent string) (any, bool) {
 		rv := reflect.ValueOf(v)